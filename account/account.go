@@ -1,17 +1,24 @@
 package account
 
 import (
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
 
 	"github.com/coming-chat/go-aptos/crypto/derivation"
 	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/tyler-smith/go-bip39"
-	"golang.org/x/crypto/blake2b"
 )
 
 const (
 	ADDRESS_LENGTH = 64
+
+	secp256PrivateKeySize = 32
 )
 
 type Account struct {
@@ -21,9 +28,8 @@ type Account struct {
 
 func NewAccount(scheme sui_types.SignatureScheme, seed []byte) *Account {
 	suiKeyPair := sui_types.NewSuiKeyPair(scheme, seed)
-	tmp := []byte{scheme.Flag()}
-	tmp = append(tmp, suiKeyPair.PublicKey()...)
-	addrBytes := blake2b.Sum256(tmp)
+	pubKey := sui_types.PublicKey{SignatureScheme: scheme, Data: suiKeyPair.PublicKey()}
+	addrBytes := pubKey.SuiAddress()
 	address := "0x" + hex.EncodeToString(addrBytes[:])[:ADDRESS_LENGTH]
 
 	return &Account{
@@ -53,7 +59,29 @@ func NewAccountWithMnemonicAndPassword(mnemonic string, password string) (*Accou
 	if err != nil {
 		return nil, err
 	}
-	key, err := derivation.DeriveForPath("m/44'/784'/0'/0'/0'", seed)
+	return newEd25519AccountWithSeed(seed, 0)
+}
+
+// DeriveEd25519Accounts derives the accounts 0..count-1 of the mnemonic as wallets do,
+// the path of account i is m/44'/784'/i'/0'/0', and account 0 is the same as NewAccountWithMnemonic
+func DeriveEd25519Accounts(mnemonic string, count int) ([]*Account, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]*Account, 0, count)
+	for i := 0; i < count; i++ {
+		account, err := newEd25519AccountWithSeed(seed, i)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func newEd25519AccountWithSeed(seed []byte, index int) (*Account, error) {
+	key, err := derivation.DeriveForPath(fmt.Sprintf("m/44'/784'/%d'/0'/0'", index), seed)
 	if err != nil {
 		return nil, err
 	}
@@ -64,15 +92,88 @@ func NewAccountWithMnemonicAndPassword(mnemonic string, password string) (*Accou
 	return NewAccount(scheme, key.Key), nil
 }
 
+// NewEd25519SignerFromSeed creates the ed25519 account of the 32 bytes seed, which is the private key of Sui keystore
+func NewEd25519SignerFromSeed(seed []byte) (*Account, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid ed25519 seed length %d, expected %d", len(seed), ed25519.SeedSize)
+	}
+	return newAccountWithPrivateKey(0, seed)
+}
+
+// NewSecp256k1SignerFromPrivateKey creates the secp256k1 account of the 32 bytes big endian private key,
+// which should be in [1, N-1] of the curve
+func NewSecp256k1SignerFromPrivateKey(privateKey []byte) (*Account, error) {
+	if len(privateKey) != secp256PrivateKeySize {
+		return nil, fmt.Errorf(
+			"invalid secp256k1 private key length %d, expected %d", len(privateKey), secp256PrivateKeySize,
+		)
+	}
+	var scalar secp256k1.ModNScalar
+	if scalar.SetByteSlice(privateKey) || scalar.IsZero() {
+		return nil, errors.New("invalid secp256k1 private key: out of range")
+	}
+	scalar.Zero()
+	return newAccountWithPrivateKey(1, privateKey)
+}
+
+// NewSecp256r1SignerFromPrivateKey creates the secp256r1 account of the 32 bytes big endian private key,
+// which should be in [1, N-1] of the curve
+func NewSecp256r1SignerFromPrivateKey(privateKey []byte) (*Account, error) {
+	if len(privateKey) != secp256PrivateKeySize {
+		return nil, fmt.Errorf(
+			"invalid secp256r1 private key length %d, expected %d", len(privateKey), secp256PrivateKeySize,
+		)
+	}
+	d := new(big.Int).SetBytes(privateKey)
+	if d.Sign() == 0 || d.Cmp(elliptic.P256().Params().N) >= 0 {
+		return nil, errors.New("invalid secp256r1 private key: out of range")
+	}
+	return newAccountWithPrivateKey(2, privateKey)
+}
+
+func newAccountWithPrivateKey(flag byte, privateKey []byte) (*Account, error) {
+	scheme, err := sui_types.NewSignatureScheme(flag)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccount(scheme, privateKey), nil
+}
+
+// Close zeros the private key held by the account, Sign panics and the other signing methods
+// return sui_types.ErrKeyPairClosed afterwards.
+// The private key passed to the constructor is owned by the caller and is not zeroed
+func (a *Account) Close() {
+	a.KeyPair.Zero()
+}
+
+// Sign panics if the account is closed by Close, use TrySign to get the error instead
 func (a *Account) Sign(data []byte) []byte {
 	switch a.KeyPair.Flag() {
 	case 0:
 		return a.KeyPair.Ed25519.Sign(data)
+	case 1:
+		return a.KeyPair.Secp256k1.Sign(data)
+	case 2:
+		return a.KeyPair.Secp256r1.Sign(data)
 	default:
 		return []byte{}
 	}
 }
 
+// TrySign returns sui_types.ErrKeyPairClosed if the account is closed by Close
+func (a *Account) TrySign(data []byte) ([]byte, error) {
+	switch {
+	case a.KeyPair.Flag() == 0 && a.KeyPair.Ed25519 != nil:
+		return a.KeyPair.Ed25519.TrySign(data)
+	case a.KeyPair.Flag() == 1 && a.KeyPair.Secp256k1 != nil:
+		return a.KeyPair.Secp256k1.TrySign(data)
+	case a.KeyPair.Flag() == 2 && a.KeyPair.Secp256r1 != nil:
+		return a.KeyPair.Secp256r1.TrySign(data)
+	default:
+		return nil, errors.New("unsupported scheme")
+	}
+}
+
 func (a *Account) SignSecureWithoutEncode(txnBytes []byte, intent sui_types.Intent) (sui_types.Signature, error) {
 	message := sui_types.NewIntentMessage(intent, bcsBytes(txnBytes))
 	signature, err := sui_types.NewSignatureSecure(message, &a.KeyPair)
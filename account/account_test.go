@@ -1,6 +1,8 @@
 package account
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
 	"encoding/json"
 	"os"
 	"testing"
@@ -37,3 +39,91 @@ func Test_Signature_Marshal_Unmarshal(t *testing.T) {
 
 	require.Equal(t, signature1, signature2)
 }
+
+func TestDeriveEd25519Accounts(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	accounts, err := DeriveEd25519Accounts(mnemonic, 3)
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+
+	first, err := NewAccountWithMnemonic(mnemonic)
+	require.NoError(t, err)
+	require.Equal(t, first.Address, accounts[0].Address)
+	require.NotEqual(t, accounts[0].Address, accounts[1].Address)
+	require.NotEqual(t, accounts[1].Address, accounts[2].Address)
+
+	_, err = DeriveEd25519Accounts(
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", 1,
+	)
+	require.Error(t, err)
+}
+
+func TestNewSignerFromPrivateKey(t *testing.T) {
+	one := make([]byte, 32)
+	one[31] = 1
+	tests := []struct {
+		name      string
+		newSigner func([]byte) (*Account, error)
+		publicKey string
+	}{
+		{
+			name:      "ed25519",
+			newSigner: NewEd25519SignerFromSeed,
+			publicKey: hex.EncodeToString(ed25519.NewKeyFromSeed(one).Public().(ed25519.PublicKey)),
+		},
+		{
+			// the public key of 1 is the generator
+			name:      "secp256k1",
+			newSigner: NewSecp256k1SignerFromPrivateKey,
+			publicKey: "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		},
+		{
+			name:      "secp256r1",
+			newSigner: NewSecp256r1SignerFromPrivateKey,
+			publicKey: "036b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := tt.newSigner(one)
+			require.NoError(t, err)
+			require.Equal(t, tt.publicKey, hex.EncodeToString(signer.KeyPair.PublicKey()))
+			pubKey := sui_types.PublicKey{SignatureScheme: signer.KeyPair.SignatureScheme, Data: signer.KeyPair.PublicKey()}
+			address := pubKey.SuiAddress()
+			require.Equal(t, address.String(), signer.Address)
+
+			txBytes := []byte("transaction bytes")
+			signature, err := signer.SignSecureWithoutEncode(txBytes, sui_types.DefaultIntent())
+			require.NoError(t, err)
+			require.NoError(t, sui_types.VerifyTransactionSignature(txBytes, signature))
+
+			sig, err := signer.TrySign(txBytes)
+			require.NoError(t, err)
+			require.Len(t, signer.Sign(txBytes), len(sig))
+
+			signer.Close()
+			require.Equal(t, make([]byte, 32), signer.KeyPair.PrivateKey()[:32])
+			require.Equal(t, byte(1), one[31])
+			_, err = signer.TrySign(txBytes)
+			require.ErrorIs(t, err, sui_types.ErrKeyPairClosed)
+			require.PanicsWithError(t, sui_types.ErrKeyPairClosed.Error(), func() { signer.Sign(txBytes) })
+			require.Panics(t, func() { signer.KeyPair.Sign(txBytes) })
+			_, err = signer.SignSecureWithoutEncode(txBytes, sui_types.DefaultIntent())
+			require.ErrorIs(t, err, sui_types.ErrKeyPairClosed)
+			_, err = signer.KeyPair.SignWithIntent(txBytes, sui_types.DefaultIntent())
+			require.ErrorIs(t, err, sui_types.ErrKeyPairClosed)
+			_, err = signer.KeyPair.SignPersonalMessage(txBytes)
+			require.ErrorIs(t, err, sui_types.ErrKeyPairClosed)
+
+			_, err = tt.newSigner(one[1:])
+			require.Error(t, err)
+		})
+	}
+
+	_, err := NewSecp256k1SignerFromPrivateKey(make([]byte, 32))
+	require.Error(t, err)
+	order, err := hex.DecodeString("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551")
+	require.NoError(t, err)
+	_, err = NewSecp256r1SignerFromPrivateKey(order)
+	require.Error(t, err)
+}
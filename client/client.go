@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -37,9 +38,41 @@ type Client struct {
 
 	rpcUrl string
 	client *http.Client
+
+	protocolConfigs protocolConfigCache
+	// moveFunctionTypeParams the count of the type parameters of `package::module::function`, packages are immutable
+	moveFunctionTypeParams sync.Map
+}
+
+// Network is the chain identifier returned by `sui_getChainIdentifier`,
+// the devnet identifier changes after each reset, so it is not listed
+type Network string
+
+const (
+	NetworkMainnet Network = "35834a8a"
+	NetworkTestnet Network = "4c78adac"
+)
+
+type DialOption func(*dialOptions)
+
+type dialOptions struct {
+	expectedNetwork Network
+}
+
+// WithExpectedNetwork fetches the chain identifier on dial, and fails with ErrNetworkMismatch
+// if it is not the expected network. It costs an extra call, so it is disabled by default
+func WithExpectedNetwork(network Network) DialOption {
+	return func(o *dialOptions) {
+		o.expectedNetwork = network
+	}
 }
 
-func Dial(rpcUrl string) (client *Client, err error) {
+func Dial(rpcUrl string, opts ...DialOption) (client *Client, err error) {
+	return DialContext(context.Background(), rpcUrl, opts...)
+}
+
+// DialContext is Dial with the context of the calls made on dial, e.g. by WithExpectedNetwork
+func DialContext(ctx context.Context, rpcUrl string, opts ...DialOption) (client *Client, err error) {
 	hc := &http.Client{
 		Transport: &http.Transport{
 			MaxIdleConns:    3,
@@ -47,14 +80,39 @@ func Dial(rpcUrl string) (client *Client, err error) {
 		},
 		Timeout: 30 * time.Second,
 	}
-	return DialWithClient(rpcUrl, hc)
+	return DialWithClientContext(ctx, rpcUrl, hc, opts...)
 }
 
-func DialWithClient(rpcUrl string, c *http.Client) (client *Client, err error) {
+func DialWithClient(rpcUrl string, c *http.Client, opts ...DialOption) (client *Client, err error) {
+	return DialWithClientContext(context.Background(), rpcUrl, c, opts...)
+}
+
+// DialWithClientContext is DialWithClient with the context of the calls made on dial, e.g. by WithExpectedNetwork
+func DialWithClientContext(
+	ctx context.Context,
+	rpcUrl string,
+	c *http.Client,
+	opts ...DialOption,
+) (client *Client, err error) {
 	client = &Client{
 		rpcUrl: strings.TrimRight(rpcUrl, "/"),
 		client: c,
 	}
+	var dialOpts dialOptions
+	for _, opt := range opts {
+		opt(&dialOpts)
+	}
+	if dialOpts.expectedNetwork != "" {
+		chainIdentifier, err := client.GetChainIdentifier(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if Network(chainIdentifier) != dialOpts.expectedNetwork {
+			return nil, fmt.Errorf(
+				"%w: expected %v but %v is %v", ErrNetworkMismatch, dialOpts.expectedNetwork, rpcUrl, chainIdentifier,
+			)
+		}
+	}
 	return
 }
 
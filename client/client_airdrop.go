@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// AirdropMaxRecipientsPerTx the default number of recipients of one airdrop transaction,
+// the SplitCoins command takes one argument per recipient, which is limited to 512 by the protocol,
+// and every recipient takes a TransferObjects command, which is limited to 1024 commands per transaction
+const AirdropMaxRecipientsPerTx = 500
+
+type AirdropRecipient struct {
+	Address suiAddress
+	Amount  uint64
+}
+
+// AirdropProgress is reported after each chunk is executed successfully
+type AirdropProgress struct {
+	Chunk       int
+	TotalChunks int
+	Response    *types.SuiTransactionBlockResponse
+}
+
+type AirdropResult struct {
+	// Responses of the chunks executed by this call, in order
+	Responses []*types.SuiTransactionBlockResponse
+	// NextChunk is the first chunk not executed, which is the StartChunk to resume after a failure
+	NextChunk   int
+	TotalChunks int
+}
+
+// AirdropChunkError the chunk failed, the chunks before it have been executed
+type AirdropChunkError struct {
+	Chunk int
+	Err   error
+}
+
+func (e *AirdropChunkError) Error() string {
+	return fmt.Sprintf("airdrop chunk %d failed: %v", e.Chunk, e.Err)
+}
+
+func (e *AirdropChunkError) Unwrap() error {
+	return e.Err
+}
+
+type AirdropOption func(*airdropOptions)
+
+type airdropOptions struct {
+	chunkSize  int
+	startChunk int
+	onProgress func(AirdropProgress)
+	sendOpts   []SendOption
+}
+
+// WithAirdropChunkSize the number of recipients per transaction, default is AirdropMaxRecipientsPerTx
+func WithAirdropChunkSize(size int) AirdropOption {
+	return func(o *airdropOptions) {
+		o.chunkSize = size
+	}
+}
+
+// WithAirdropStartChunk skips the chunks before start, use AirdropResult.NextChunk to resume a failed airdrop
+// with the same recipients and chunk size
+func WithAirdropStartChunk(start int) AirdropOption {
+	return func(o *airdropOptions) {
+		o.startChunk = start
+	}
+}
+
+func WithAirdropProgress(onProgress func(AirdropProgress)) AirdropOption {
+	return func(o *airdropOptions) {
+		o.onProgress = onProgress
+	}
+}
+
+// WithAirdropSendOptions are passed to SignAndExecuteTransaction of each chunk
+func WithAirdropSendOptions(opts ...SendOption) AirdropOption {
+	return func(o *airdropOptions) {
+		o.sendOpts = opts
+	}
+}
+
+// Airdrop pays SUI to the recipients, which are split into chunks of one transaction each. The chunks are executed
+// one by one, the amounts are split from the gas coin, so gasPayment must cover the amounts and gasBudget of a chunk.
+// The gas coin of each chunk is the gas object in the effects of the last chunk.
+//
+// The execution stops at the first failed chunk, which is returned as AirdropChunkError with the result of
+// the chunks executed before. Resume with WithAirdropStartChunk(result.NextChunk) and the latest gas coins.
+func (c *Client) Airdrop(
+	ctx context.Context,
+	sender suiAddress,
+	signer TransactionSigner,
+	recipients []AirdropRecipient,
+	gasPayment []*sui_types.ObjectRef,
+	gasBudget uint64,
+	opts ...AirdropOption,
+) (*AirdropResult, error) {
+	airdropOpts := airdropOptions{chunkSize: AirdropMaxRecipientsPerTx}
+	for _, opt := range opts {
+		opt(&airdropOpts)
+	}
+	if airdropOpts.chunkSize <= 0 || airdropOpts.chunkSize > AirdropMaxRecipientsPerTx {
+		return nil, fmt.Errorf("chunk size should be in [1, %d]", AirdropMaxRecipientsPerTx)
+	}
+	if len(gasPayment) == 0 {
+		return nil, errors.New("gas payment is empty")
+	}
+	chunks := chunkAirdropRecipients(recipients, airdropOpts.chunkSize)
+	result := &AirdropResult{NextChunk: airdropOpts.startChunk, TotalChunks: len(chunks)}
+	if airdropOpts.startChunk < 0 || airdropOpts.startChunk > len(chunks) {
+		return result, fmt.Errorf("start chunk %d is out of [0, %d]", airdropOpts.startChunk, len(chunks))
+	}
+
+	gasPrice, err := c.GetReferenceGasPrice(ctx)
+	if err != nil {
+		return result, err
+	}
+	for i := airdropOpts.startChunk; i < len(chunks); i++ {
+		ptb := sui_types.NewProgrammableTransactionBuilder()
+		addresses := make([]suiAddress, len(chunks[i]))
+		amounts := make([]uint64, len(chunks[i]))
+		for j, recipient := range chunks[i] {
+			addresses[j], amounts[j] = recipient.Address, recipient.Amount
+		}
+		err = ptb.PaySui(addresses, amounts)
+		if err != nil {
+			return result, &AirdropChunkError{Chunk: i, Err: err}
+		}
+		tx := sui_types.NewProgrammable(sender, gasPayment, ptb.Finish(), gasBudget, gasPrice.Uint64())
+		resp, err := c.SignAndExecuteTransaction(ctx, tx, signer, nil, airdropOpts.sendOpts...)
+		if err != nil {
+			return result, &AirdropChunkError{Chunk: i, Err: err}
+		}
+		if resp.Effects == nil || resp.Effects.Data.V1 == nil {
+			return result, &AirdropChunkError{Chunk: i, Err: errors.New("no effects in the response")}
+		}
+		if !resp.Effects.Data.IsSuccess() {
+			err = fmt.Errorf("execution failed: %v", resp.Effects.Data.V1.Status.Error)
+			return result, &AirdropChunkError{Chunk: i, Err: err}
+		}
+		gasRef, err := resp.Effects.Data.V1.GasObject.Reference.ObjectRef()
+		if err != nil {
+			return result, &AirdropChunkError{Chunk: i, Err: err}
+		}
+		gasPayment = []*sui_types.ObjectRef{gasRef}
+
+		result.Responses = append(result.Responses, resp)
+		result.NextChunk = i + 1
+		if airdropOpts.onProgress != nil {
+			airdropOpts.onProgress(AirdropProgress{Chunk: i, TotalChunks: len(chunks), Response: resp})
+		}
+	}
+	return result, nil
+}
+
+func chunkAirdropRecipients(recipients []AirdropRecipient, size int) [][]AirdropRecipient {
+	var chunks [][]AirdropRecipient
+	for start := 0; start < len(recipients); start += size {
+		end := start + size
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+		chunks = append(chunks, recipients[start:end])
+	}
+	return chunks
+}
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkAirdropRecipients(t *testing.T) {
+	recipients := make([]AirdropRecipient, 1001)
+	for i := range recipients {
+		recipients[i].Amount = uint64(i)
+	}
+	chunks := chunkAirdropRecipients(recipients, AirdropMaxRecipientsPerTx)
+	require.Len(t, chunks, 3)
+	require.Len(t, chunks[0], 500)
+	require.Len(t, chunks[2], 1)
+	require.Equal(t, uint64(1000), chunks[2][0].Amount)
+	require.Empty(t, chunkAirdropRecipients(nil, AirdropMaxRecipientsPerTx))
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// BagValueDecoder returns a new pointer to decode a value of the Bag into, e.g. `func() any { return new(Pool) }`
+type BagValueDecoder func() any
+
+// BagValueTypes the decoders of the value types in the Bag, keyed by the value type, e.g. `0x2::coin::Coin<0x2::sui::SUI>`.
+// The types are compared after parsing, so the addresses can be short or full length.
+type BagValueTypes map[string]BagValueDecoder
+
+type BagEntry struct {
+	Name sui_types.DynamicFieldName
+	// ValueType the type of the value, which is the type of the object in an ObjectBag
+	ValueType string
+	// ObjectId the object of the dynamic field in a Bag, or the value object in an ObjectBag
+	ObjectId suiObjectID
+	// Value the pointer returned by the decoder of ValueType and decoded, nil if there is no decoder of the type
+	Value any
+}
+
+// GetBagEntries enumerates the dynamic fields of the Bag or ObjectBag `bagId`, which is the id of the UID in the bag,
+// and decodes the values with the decoder of their types, the values of the types without a decoder are not fetched.
+// The values of a Bag are the `value` field of the `Field<K, V>` objects, and the values of an ObjectBag are
+// the objects themselves, both are decoded as SuiParsedMoveObject.DecodeFields does.
+// @param maxPages the max number of pages of the dynamic fields, default is `DefaultMaxPages`
+func (c *Client) GetBagEntries(
+	ctx context.Context,
+	bagId suiObjectID,
+	valueTypes BagValueTypes,
+	maxPages int,
+) ([]BagEntry, error) {
+	decoders := make(map[string]BagValueDecoder, len(valueTypes))
+	for typ, decoder := range valueTypes {
+		decoders[normalizeTypeString(typ)] = decoder
+	}
+
+	fields, err := CollectAllPages(
+		ctx, func(ctx context.Context, cursor *suiObjectID) (*types.DynamicFieldPage, error) {
+			return c.GetDynamicFields(ctx, bagId, cursor, nil)
+		}, maxPages,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]BagEntry, len(fields))
+	var (
+		decodeIndexes []int
+		decodeIds     []suiObjectID
+	)
+	for i, field := range fields {
+		entries[i] = BagEntry{Name: field.Name, ValueType: field.ObjectType, ObjectId: field.ObjectId}
+		if decoders[normalizeTypeString(field.ObjectType)] != nil {
+			decodeIndexes = append(decodeIndexes, i)
+			decodeIds = append(decodeIds, field.ObjectId)
+		}
+	}
+	if len(decodeIds) == 0 {
+		return entries, nil
+	}
+
+	objects, err := c.multiGetObjectsChunked(ctx, decodeIds, &types.SuiObjectDataOptions{ShowContent: true})
+	if err != nil {
+		return nil, err
+	}
+	for j, obj := range objects {
+		i := decodeIndexes[j]
+		if obj.Data == nil || obj.Data.Content == nil || obj.Data.Content.Data.MoveObject == nil {
+			return nil, fmt.Errorf("no move object of the bag value %v", entries[i].ObjectId)
+		}
+		moveObject := obj.Data.Content.Data.MoveObject
+		value := decoders[normalizeTypeString(entries[i].ValueType)]()
+		if fields[i].Type.Data.DynamicObject != nil {
+			err = moveObject.DecodeFields(value)
+		} else {
+			err = moveObject.DecodeField("value", value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid bag value %v of type %v: %w", entries[i].ObjectId, entries[i].ValueType, err)
+		}
+		entries[i].Value = value
+	}
+	return entries, nil
+}
+
+// normalizeTypeString formats the type as move_types.TypeTag does, the invalid type is returned as is
+func normalizeTypeString(typ string) string {
+	tag, err := move_types.ParseTypeTag(typ)
+	if err != nil {
+		return typ
+	}
+	return tag.String()
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	bagDynamicFieldsResponse = `{"data":[
+		{"name":{"type":"u64","value":"1"},"bcsName":"2","type":"DynamicField","objectType":"u64",
+			"objectId":"0x11","version":1,"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"},
+		{"name":{"type":"u64","value":"2"},"bcsName":"3","type":"DynamicField",
+			"objectType":"0x000000000000000000000000000000000000000000000000000000000000000a::pool::Config",
+			"objectId":"0x12","version":1,"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"},
+		{"name":{"type":"u64","value":"3"},"bcsName":"4","type":"DynamicObject","objectType":"0xa::pool::Pool",
+			"objectId":"0x13","version":1,"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"},
+		{"name":{"type":"u64","value":"4"},"bcsName":"5","type":"DynamicField","objectType":"0xa::pool::Unknown",
+			"objectId":"0x14","version":1,"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}
+	],"nextCursor":"0x14","hasNextPage":false}`
+	bagObjectsResponse = `[
+		{"data":{"objectId":"0x11","version":"1","digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn",
+			"content":{"dataType":"moveObject","type":"0x2::dynamic_field::Field<u64, u64>","hasPublicTransfer":false,
+				"fields":{"id":{"id":"0x11"},"name":"1","value":"100"}}}},
+		{"data":{"objectId":"0x12","version":"1","digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn",
+			"content":{"dataType":"moveObject","type":"0x2::dynamic_field::Field<u64, 0xa::pool::Config>","hasPublicTransfer":false,
+				"fields":{"id":{"id":"0x12"},"name":"2","value":{"type":"0xa::pool::Config","fields":{"fee":"30"}}}}}},
+		{"data":{"objectId":"0x13","version":"1","digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn",
+			"content":{"dataType":"moveObject","type":"0xa::pool::Pool","hasPublicTransfer":true,
+				"fields":{"id":{"id":"0x13"},"liquidity":"5000"}}}}
+	]`
+)
+
+func TestClient_GetBagEntries(t *testing.T) {
+	var requestedIds []sui_types.ObjectID
+	cli := newFakeNode(
+		t, map[string]any{
+			"suix_getDynamicFields": bagDynamicFieldsResponse,
+			"sui_multiGetObjects": func(params []json.RawMessage) any {
+				require.NoError(t, json.Unmarshal(params[0], &requestedIds))
+				return bagObjectsResponse
+			},
+		},
+	)
+
+	type config struct {
+		Fee types.SafeSuiBigInt[uint64] `json:"fee"`
+	}
+	type pool struct {
+		Liquidity types.SafeSuiBigInt[uint64] `json:"liquidity"`
+	}
+	entries, err := cli.GetBagEntries(
+		context.Background(), sui_types.ObjectID{31: 1}, BagValueTypes{
+			"u64":               func() any { return new(types.SafeSuiBigInt[uint64]) },
+			"0xa::pool::Config": func() any { return new(config) },
+			"0x000000000000000000000000000000000000000000000000000000000000000a::pool::Pool": func() any {
+				return new(pool)
+			},
+		}, 0,
+	)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	require.Equal(t, []sui_types.ObjectID{{31: 0x11}, {31: 0x12}, {31: 0x13}}, requestedIds)
+
+	require.Equal(t, uint64(100), entries[0].Value.(*types.SafeSuiBigInt[uint64]).Uint64())
+	require.Equal(t, uint64(30), entries[1].Value.(*config).Fee.Uint64())
+	require.Equal(t, uint64(5000), entries[2].Value.(*pool).Liquidity.Uint64())
+	require.Equal(t, "0xa::pool::Unknown", entries[3].ValueType)
+	require.Nil(t, entries[3].Value)
+	require.Equal(t, "4", entries[3].Name.Value)
+}
@@ -2,16 +2,28 @@ package client
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/move_types"
 	"github.com/coming-chat/go-sui/v2/sui_types"
 	"github.com/coming-chat/go-sui/v2/types"
 )
 
 // NOTE: This copys the query limit from our Rust JSON RPC backend, this needs to be kept in sync!
-const QUERY_MAX_RESULT_LIMIT = 1000
+// The limits larger than these are rejected with ErrLimitExceeded before calling the node
+const (
+	// QUERY_MAX_RESULT_LIMIT coins, events, transaction blocks and names
+	QUERY_MAX_RESULT_LIMIT = 1000
+	// QUERY_MAX_RESULT_LIMIT_OBJECTS owned objects, dynamic fields and the ids of multi get objects
+	QUERY_MAX_RESULT_LIMIT_OBJECTS     = 50
+	QUERY_MAX_RESULT_LIMIT_CHECKPOINTS = 100
+)
 
 type suiAddress = sui_types.SuiAddress
 type suiObjectID = sui_types.ObjectID
@@ -30,11 +42,56 @@ func (c *Client) GetBalance(ctx context.Context, owner suiAddress, coinType stri
 	}
 }
 
+// GetSuiBalance returns the total SUI balance of owner in MIST (1 SUI = 10^9 MIST)
+func (c *Client) GetSuiBalance(ctx context.Context, owner suiAddress) (types.SuiBigInt, error) {
+	balance, err := c.GetBalance(ctx, owner, types.SuiCoinType)
+	if err != nil {
+		return types.SuiBigInt{}, err
+	}
+	return balance.TotalBalance, nil
+}
+
+// GetSuiBalanceFormatted returns the total SUI balance of owner in SUI, e.g. "1.5" for 1500000000 MIST
+func (c *Client) GetSuiBalanceFormatted(ctx context.Context, owner suiAddress) (string, error) {
+	balance, err := c.GetSuiBalance(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+	return balance.Shift(-types.SuiDecimals).String(), nil
+}
+
 func (c *Client) GetAllBalances(ctx context.Context, owner suiAddress) ([]types.Balance, error) {
 	var resp []types.Balance
 	return resp, c.CallContext(ctx, &resp, getAllBalances, owner)
 }
 
+// GetOwnedCoinTypes returns the distinct coin types held by owner, in the normalized format of the node,
+// e.g. `0x2::sui::SUI`. SUI is always included if includeSui is true, even if the balance is zero
+func (c *Client) GetOwnedCoinTypes(ctx context.Context, owner suiAddress, includeSui bool) ([]string, error) {
+	balances, err := c.GetAllBalances(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	var coinTypes []string
+	seen := make(map[string]bool)
+	if includeSui {
+		coinTypes = append(coinTypes, types.SuiCoinType)
+		seen[types.SuiCoinType] = true
+	}
+	for _, balance := range balances {
+		tag, err := move_types.ParseStructTag(balance.CoinType)
+		if err != nil {
+			return nil, err
+		}
+		coinType := tag.String()
+		if !seen[coinType] {
+			seen[coinType] = true
+			coinTypes = append(coinTypes, coinType)
+		}
+	}
+	return coinTypes, nil
+}
+
 // GetSuiCoinsOwnedByAddress This function will retrieve a maximum of 200 coins.
 func (c *Client) GetSuiCoinsOwnedByAddress(ctx context.Context, address suiAddress) (types.Coins, error) {
 	coinType := types.SuiCoinType
@@ -54,6 +111,9 @@ func (c *Client) GetCoins(
 	cursor *suiObjectID,
 	limit uint,
 ) (*types.CoinPage, error) {
+	if err := checkLimit(getCoins, limit, QUERY_MAX_RESULT_LIMIT); err != nil {
+		return nil, err
+	}
 	var resp types.CoinPage
 	return &resp, c.CallContext(ctx, &resp, getCoins, owner, coinType, cursor, limit)
 }
@@ -66,15 +126,198 @@ func (c *Client) GetAllCoins(
 	cursor *suiObjectID,
 	limit uint,
 ) (*types.CoinPage, error) {
+	if err := checkLimit(getAllCoins, limit, QUERY_MAX_RESULT_LIMIT); err != nil {
+		return nil, err
+	}
 	var resp types.CoinPage
 	return &resp, c.CallContext(ctx, &resp, getAllCoins, owner, cursor, limit)
 }
 
+// GetCoinsWithBalance fetches all pages of the coins of coinType owned by owner,
+// to use default sui coin(0x2::sui::SUI) when coinType is empty
+func (c *Client) GetCoinsWithBalance(ctx context.Context, owner suiAddress, coinType string) (types.Coins, error) {
+	if coinType == "" {
+		coinType = types.SuiCoinType
+	}
+	coins, err := CollectAllPages(
+		ctx, func(ctx context.Context, cursor *string) (*types.CoinPage, error) {
+			var coinCursor *suiObjectID
+			if cursor != nil {
+				id, err := sui_types.NewObjectIdFromHex(*cursor)
+				if err != nil {
+					return nil, fmt.Errorf("invalid coin cursor %v: %w", *cursor, err)
+				}
+				coinCursor = id
+			}
+			return c.GetCoins(ctx, owner, &coinType, coinCursor, QUERY_MAX_RESULT_LIMIT)
+		}, 0,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return coins, nil
+}
+
+// GetCoinBalance returns the balance of the coin object coinId, which should be a `0x2::coin::Coin<T>` of any T,
+// ErrNotCoin is returned for the other objects
+func (c *Client) GetCoinBalance(ctx context.Context, coinId suiObjectID) (types.SuiBigInt, error) {
+	obj, err := c.GetObject(ctx, coinId, &types.SuiObjectDataOptions{ShowContent: true})
+	if err != nil {
+		return types.SuiBigInt{}, err
+	}
+	if obj.Data == nil {
+		return types.SuiBigInt{}, fmt.Errorf("coin %v not found", coinId)
+	}
+	if obj.Data.Content == nil || obj.Data.Content.Data.MoveObject == nil {
+		return types.SuiBigInt{}, fmt.Errorf("%w: %v is not a move object", ErrNotCoin, coinId)
+	}
+	moveObject := obj.Data.Content.Data.MoveObject
+	if !isCoinType(moveObject.Type) {
+		return types.SuiBigInt{}, fmt.Errorf("%w: %v is %v", ErrNotCoin, coinId, moveObject.Type)
+	}
+	var balance types.SafeSuiBigInt[uint64]
+	if err := moveObject.DecodeField("balance", &balance); err != nil {
+		return types.SuiBigInt{}, fmt.Errorf("invalid balance of coin %v: %w", coinId, err)
+	}
+	return balance.Decimal(), nil
+}
+
+// isCoinType checks the object type is a `0x2::coin::Coin<T>`
+func isCoinType(objectType string) bool {
+	tag, err := move_types.ParseStructTag(objectType)
+	if err != nil || len(tag.TypeParams) != 1 {
+		return false
+	}
+	tag.TypeParams = nil
+	return tag.String() == "0x2::coin::Coin"
+}
+
+// PreflightPayment checks that owner has enough coins of coinType for the amount and enough SUI for the gas budget
+// before building the payment, to use default sui coin(0x2::sui::SUI) when coinType is empty.
+// An *InsufficientBalanceError with the shortfall is returned if a balance is not enough, which is
+// types.ErrInsufficientBalance. The balances are checked first, then the coins are fetched and selected by
+// types.SelectCoinsForPaymentAndGas, whose error is returned if the coins can't be selected, e.g. types.ErrNeedMergeCoin
+func (c *Client) PreflightPayment(
+	ctx context.Context,
+	owner suiAddress,
+	coinType string,
+	amount uint64,
+	estGasBudget uint64,
+) error {
+	if coinType == "" {
+		coinType = types.SuiCoinType
+	}
+	gas := types.NewSafeSuiBigInt(estGasBudget).Decimal()
+	pay := types.NewSafeSuiBigInt(amount).Decimal()
+	isSui := types.NormalizeCoinType(coinType) == types.SuiCoinType
+	if isSui {
+		gas = gas.Add(pay)
+	} else {
+		err := c.checkBalance(ctx, owner, coinType, pay)
+		if err != nil {
+			return err
+		}
+	}
+	err := c.checkBalance(ctx, owner, types.SuiCoinType, gas)
+	if err != nil {
+		return err
+	}
+
+	coins, err := c.GetCoinsWithBalance(ctx, owner, types.SuiCoinType)
+	if err != nil {
+		return err
+	}
+	if !isSui {
+		payCoins, err := c.GetCoinsWithBalance(ctx, owner, coinType)
+		if err != nil {
+			return err
+		}
+		coins = append(coins, payCoins...)
+	}
+	_, err = types.SelectCoinsForPaymentAndGas(coins, amount, estGasBudget)
+	return err
+}
+
+// checkBalance returns an *InsufficientBalanceError if the total balance of coinType is less than required
+func (c *Client) checkBalance(ctx context.Context, owner suiAddress, coinType string, required types.SuiBigInt) error {
+	balance, err := c.GetBalance(ctx, owner, coinType)
+	if err != nil {
+		return err
+	}
+	if balance.TotalBalance.LessThan(required) {
+		return &InsufficientBalanceError{
+			CoinType:  types.NormalizeCoinType(coinType),
+			Required:  required,
+			Available: balance.TotalBalance,
+		}
+	}
+	return nil
+}
+
 func (c *Client) GetCoinMetadata(ctx context.Context, coinType string) (*types.SuiCoinMetadata, error) {
 	var resp types.SuiCoinMetadata
 	return &resp, c.CallContext(ctx, &resp, getCoinMetadata, coinType)
 }
 
+// GetCoinMetadataByObjectId fetches and decodes the `0x2::coin::CoinMetadata<T>` object metadataId, which works for
+// the metadata not indexed by suix_getCoinMetadata, ErrNotCoinMetadata is returned for the other objects
+func (c *Client) GetCoinMetadataByObjectId(ctx context.Context, metadataId suiObjectID) (*types.SuiCoinMetadata, error) {
+	obj, err := c.GetObject(ctx, metadataId, &types.SuiObjectDataOptions{ShowContent: true})
+	if err != nil {
+		return nil, err
+	}
+	if obj.Data == nil {
+		return nil, fmt.Errorf("coin metadata %v not found", metadataId)
+	}
+	if obj.Data.Content == nil || obj.Data.Content.Data.MoveObject == nil {
+		return nil, fmt.Errorf("%w: %v is not a move object", ErrNotCoinMetadata, metadataId)
+	}
+	moveObject := obj.Data.Content.Data.MoveObject
+	if !isCoinMetadataType(moveObject.Type) {
+		return nil, fmt.Errorf("%w: %v is %v", ErrNotCoinMetadata, metadataId, moveObject.Type)
+	}
+	var fields struct {
+		Decimals    uint8           `json:"decimals"`
+		Name        string          `json:"name"`
+		Symbol      string          `json:"symbol"`
+		Description string          `json:"description"`
+		IconUrl     json.RawMessage `json:"icon_url"`
+	}
+	if err := moveObject.DecodeFields(&fields); err != nil {
+		return nil, fmt.Errorf("invalid coin metadata %v: %w", metadataId, err)
+	}
+	metadata := &types.SuiCoinMetadata{
+		Decimals:    fields.Decimals,
+		Description: fields.Description,
+		Id:          metadataId,
+		Name:        fields.Name,
+		Symbol:      fields.Symbol,
+	}
+	// the `Option<Url>` is null, the url string, or the Url struct
+	if len(fields.IconUrl) > 0 && string(fields.IconUrl) != "null" {
+		if err := json.Unmarshal(fields.IconUrl, &metadata.IconUrl); err != nil {
+			var url struct {
+				Url string `json:"url"`
+			}
+			if err := json.Unmarshal(fields.IconUrl, &url); err != nil {
+				return nil, fmt.Errorf("invalid icon url of coin metadata %v: %w", metadataId, err)
+			}
+			metadata.IconUrl = url.Url
+		}
+	}
+	return metadata, nil
+}
+
+// isCoinMetadataType checks the object type is a `0x2::coin::CoinMetadata<T>`
+func isCoinMetadataType(objectType string) bool {
+	tag, err := move_types.ParseStructTag(objectType)
+	if err != nil || len(tag.TypeParams) != 1 {
+		return false
+	}
+	tag.TypeParams = nil
+	return tag.String() == "0x2::coin::CoinMetadata"
+}
+
 func (c *Client) GetObject(
 	ctx context.Context,
 	objID suiObjectID,
@@ -84,11 +327,85 @@ func (c *Client) GetObject(
 	return &resp, c.CallContext(ctx, &resp, getObject, objID, options)
 }
 
+// EstimateReclaimableRebate sums the `storageRebate` of the objects, which is the upper bound of the SUI reclaimed by
+// deleting them. The node keeps the non-refundable part of the rebate, see `nonRefundableStorageFee` of
+// GasCostSummary, so the actual refund is a bit less
+func (c *Client) EstimateReclaimableRebate(ctx context.Context, objIds []suiObjectID) (uint64, error) {
+	objs, err := c.multiGetObjectsChunked(ctx, objIds, &types.SuiObjectDataOptions{ShowStorageRebate: true})
+	if err != nil {
+		return 0, err
+	}
+	var total uint64
+	for i, obj := range objs {
+		if obj.Data == nil || obj.Data.StorageRebate == nil {
+			return 0, fmt.Errorf("storage rebate of object %v not found", objIds[i])
+		}
+		total += obj.Data.StorageRebate.Uint64()
+	}
+	return total, nil
+}
+
+// GetSharedObjectInitialVersion returns the `initial_shared_version` of the shared object,
+// which is required by the shared input of a transaction, see ProgrammableTransactionBuilder.SharedObj
+func (c *Client) GetSharedObjectInitialVersion(ctx context.Context, objID suiObjectID) (uint64, error) {
+	obj, err := c.GetObject(ctx, objID, &types.SuiObjectDataOptions{ShowOwner: true})
+	if err != nil {
+		return 0, err
+	}
+	if obj.Data == nil || obj.Data.Owner == nil {
+		return 0, fmt.Errorf("object %v not found", objID)
+	}
+	owner := obj.Data.Owner.ObjectOwnerInternal
+	if owner == nil || owner.Shared == nil || owner.Shared.InitialSharedVersion == nil {
+		return 0, fmt.Errorf("%w: %v", ErrObjectNotShared, objID)
+	}
+	return *owner.Shared.InitialSharedVersion, nil
+}
+
+// GetObjectBcs fetches the object with showBcs and decodes its BCS bytes into v, see types.SuiRawMoveObject.DecodeBcs
+func (c *Client) GetObjectBcs(ctx context.Context, objID suiObjectID, v any) (*types.SuiObjectData, error) {
+	obj, err := c.GetObject(ctx, objID, &types.SuiObjectDataOptions{ShowType: true, ShowBcs: true})
+	if err != nil {
+		return nil, err
+	}
+	if obj.Data == nil {
+		return nil, fmt.Errorf("object %v not found", objID)
+	}
+	if obj.Data.Bcs == nil || obj.Data.Bcs.Data.MoveObject == nil {
+		return nil, fmt.Errorf("object %v is not a move object", objID)
+	}
+	err = obj.Data.Bcs.Data.MoveObject.DecodeBcs(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bcs of object %v: %w", objID, err)
+	}
+	return obj.Data, nil
+}
+
+// GetObjectDigest returns the digest and the version of the latest object without the content.
+// The digest is the hash of the object including its content, so a changed digest means the object has changed,
+// which can be used to invalidate the cached objects cheaply. ErrObjectDeleted is returned if the object is deleted.
+func (c *Client) GetObjectDigest(ctx context.Context, objID suiObjectID) (sui_types.ObjectDigest, uint64, error) {
+	obj, err := c.GetObject(ctx, objID, nil)
+	if err != nil {
+		return sui_types.ObjectDigest{}, 0, err
+	}
+	if obj.Data == nil {
+		if obj.Error != nil && obj.Error.Data.Deleted != nil {
+			return sui_types.ObjectDigest{}, obj.Error.Data.Deleted.Version, fmt.Errorf("%w: %v", ErrObjectDeleted, objID)
+		}
+		return sui_types.ObjectDigest{}, 0, fmt.Errorf("object %v not found", objID)
+	}
+	return obj.Data.Digest, obj.Data.Version.Uint64(), nil
+}
+
 func (c *Client) MultiGetObjects(
 	ctx context.Context,
 	objIDs []suiObjectID,
 	options *types.SuiObjectDataOptions,
 ) ([]types.SuiObjectResponse, error) {
+	if err := checkLimit(multiGetObjects, uint(len(objIDs)), QUERY_MAX_RESULT_LIMIT_OBJECTS); err != nil {
+		return nil, err
+	}
 	var resp []types.SuiObjectResponse
 	return resp, c.CallContext(ctx, &resp, multiGetObjects, objIDs, options)
 }
@@ -96,7 +413,7 @@ func (c *Client) MultiGetObjects(
 // address : <SuiAddress> - the owner's Sui address
 // query : <ObjectResponseQuery> - the objects query criteria.
 // cursor : <CheckpointedObjectID> - An optional paging cursor. If provided, the query will start from the next item after the specified cursor. Default to start from the first item if not specified.
-// limit : <uint> - Max number of items returned per page, default to [QUERY_MAX_RESULT_LIMIT_OBJECTS] if is 0
+// limit : <uint> - Max number of items returned per page, default to [QUERY_MAX_RESULT_LIMIT_OBJECTS] if is nil
 func (c *Client) GetOwnedObjects(
 	ctx context.Context,
 	address suiAddress,
@@ -104,6 +421,25 @@ func (c *Client) GetOwnedObjects(
 	cursor *types.CheckpointedObjectId,
 	limit *uint,
 ) (*types.ObjectsPage, error) {
+	if err := checkLimitPtr(getOwnedObjects, limit, QUERY_MAX_RESULT_LIMIT_OBJECTS); err != nil {
+		return nil, err
+	}
+	var resp types.ObjectsPage
+	return &resp, c.CallContext(ctx, &resp, getOwnedObjects, address, query, cursor, limit)
+}
+
+// getOwnedObjectsPage is GetOwnedObjects with the cursor as the plain object id, which is the `nextCursor` of the
+// previous page, the node takes the cursor as an object id rather than a CheckpointedObjectId
+func (c *Client) getOwnedObjectsPage(
+	ctx context.Context,
+	address suiAddress,
+	query *types.SuiObjectResponseQuery,
+	cursor *suiObjectID,
+	limit *uint,
+) (*types.ObjectsPage, error) {
+	if err := checkLimitPtr(getOwnedObjects, limit, QUERY_MAX_RESULT_LIMIT_OBJECTS); err != nil {
+		return nil, err
+	}
 	var resp types.ObjectsPage
 	return &resp, c.CallContext(ctx, &resp, getOwnedObjects, address, query, cursor, limit)
 }
@@ -123,6 +459,26 @@ func (c *Client) GetLatestCheckpointSequenceNumber(ctx context.Context) (string,
 	return resp, c.CallContext(ctx, &resp, getLatestCheckpointSequenceNumber)
 }
 
+// GetCheckpoint @param checkpointId the sequence number or the digest of the checkpoint
+func (c *Client) GetCheckpoint(ctx context.Context, checkpointId string) (*types.Checkpoint, error) {
+	var resp types.Checkpoint
+	return &resp, c.CallContext(ctx, &resp, getCheckpoint, checkpointId)
+}
+
+// GetCheckpoints
+// cursor : the sequence number to start after, start with the first checkpoint when cursor is nil
+// limit : max number of checkpoints returned per page, default to [QUERY_MAX_RESULT_LIMIT_CHECKPOINTS] if is nil
+func (c *Client) GetCheckpoints(
+	ctx context.Context, cursor *string, limit *uint,
+	descendingOrder bool,
+) (*types.CheckpointPage, error) {
+	if err := checkLimitPtr(getCheckpoints, limit, QUERY_MAX_RESULT_LIMIT_CHECKPOINTS); err != nil {
+		return nil, err
+	}
+	var resp types.CheckpointPage
+	return &resp, c.CallContext(ctx, &resp, getCheckpoints, cursor, limit, descendingOrder)
+}
+
 // BatchGetObjectsOwnedByAddress @param filterType You can specify filtering out the specified resources, this will fetch all resources if it is not empty ""
 func (c *Client) BatchGetObjectsOwnedByAddress(
 	ctx context.Context,
@@ -138,6 +494,9 @@ func (c *Client) BatchGetObjectsOwnedByAddress(
 	)
 }
 
+// BatchGetFilteredObjectsOwnedByAddress gets the objects owned by address which pass the filter.
+// All the pages of the owned objects are fetched rather than only the first one, at most `DefaultMaxPages` pages,
+// ErrTooManyPages is returned if there are more
 func (c *Client) BatchGetFilteredObjectsOwnedByAddress(
 	ctx context.Context,
 	address suiAddress,
@@ -149,12 +508,16 @@ func (c *Client) BatchGetFilteredObjectsOwnedByAddress(
 			ShowType: true,
 		},
 	}
-	filteringObjs, err := c.GetOwnedObjects(ctx, address, &query, nil, nil)
+	filteringObjs, err := CollectAllPages(
+		ctx, func(ctx context.Context, cursor *suiObjectID) (*types.ObjectsPage, error) {
+			return c.getOwnedObjectsPage(ctx, address, &query, cursor, nil)
+		}, 0,
+	)
 	if err != nil {
 		return nil, err
 	}
 	objIds := make([]suiObjectID, 0)
-	for _, obj := range filteringObjs.Data {
+	for _, obj := range filteringObjs {
 		if obj.Data == nil {
 			continue // error obj
 		}
@@ -163,8 +526,56 @@ func (c *Client) BatchGetFilteredObjectsOwnedByAddress(
 		}
 		objIds = append(objIds, obj.Data.ObjectId)
 	}
+	return c.multiGetObjectsChunked(ctx, objIds, &options)
+}
+
+// GetOwnedObjectTypeCounts counts the objects owned by owner of every type, e.g. a collection of NFTs,
+// the types are normalized as move_types.TypeTag formats them, e.g. `0x2::coin::Coin<0x2::sui::SUI>`.
+// The owned objects are paged as BatchGetFilteredObjectsOwnedByAddress does, at most `DefaultMaxPages` pages
+func (c *Client) GetOwnedObjectTypeCounts(ctx context.Context, owner suiAddress) (map[string]int, error) {
+	query := types.SuiObjectResponseQuery{
+		Options: &types.SuiObjectDataOptions{
+			ShowType: true,
+		},
+	}
+	limit := uint(QUERY_MAX_RESULT_LIMIT_OBJECTS)
+	objs, err := CollectAllPages(
+		ctx, func(ctx context.Context, cursor *suiObjectID) (*types.ObjectsPage, error) {
+			return c.getOwnedObjectsPage(ctx, owner, &query, cursor, &limit)
+		}, 0,
+	)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, obj := range objs {
+		if obj.Data == nil || obj.Data.Type == nil {
+			continue // error obj
+		}
+		counts[normalizeTypeString(*obj.Data.Type)]++
+	}
+	return counts, nil
+}
 
-	return c.MultiGetObjects(ctx, objIds, &options)
+// multiGetObjectsChunked is MultiGetObjects with any number of ids, which are requested in chunks of the max limit
+func (c *Client) multiGetObjectsChunked(
+	ctx context.Context,
+	objIds []suiObjectID,
+	options *types.SuiObjectDataOptions,
+) ([]types.SuiObjectResponse, error) {
+	objs := make([]types.SuiObjectResponse, 0, len(objIds))
+	for start := 0; start < len(objIds); start += QUERY_MAX_RESULT_LIMIT_OBJECTS {
+		end := start + QUERY_MAX_RESULT_LIMIT_OBJECTS
+		if end > len(objIds) {
+			end = len(objIds)
+		}
+		chunk, err := c.MultiGetObjects(ctx, objIds[start:end], options)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, chunk...)
+	}
+	return objs, nil
 }
 
 func (c *Client) GetTransactionBlock(
@@ -176,11 +587,92 @@ func (c *Client) GetTransactionBlock(
 	return &resp, c.CallContext(ctx, &resp, getTransactionBlock, digest, options)
 }
 
+// GetLoadedChildObjects returns the child objects loaded by the transaction during the execution, which are the
+// dynamic fields read or written by the move calls, at the loaded versions. It helps to diagnose the unexpected reads.
+// ErrMethodNotSupported is returned if the node doesn't provide `sui_getLoadedChildObjects`, which is not served by
+// every node version, the changed child objects are still in the effects of the transaction then
+func (c *Client) GetLoadedChildObjects(
+	ctx context.Context,
+	digest suiDigest,
+) (*types.LoadedChildObjectsResponse, error) {
+	var resp types.LoadedChildObjectsResponse
+	err := c.CallContext(ctx, &resp, getLoadedChildObjects, digest)
+	if isMethodNotFound(err) {
+		return nil, fmt.Errorf("%w: %v: %v", ErrMethodNotSupported, getLoadedChildObjects, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetChainIdentifier returns the first 4 bytes of the genesis checkpoint digest in hex, see Network
+func (c *Client) GetChainIdentifier(ctx context.Context) (string, error) {
+	var resp string
+	return resp, c.CallContext(ctx, &resp, getChainIdentifier)
+}
+
 func (c *Client) GetReferenceGasPrice(ctx context.Context) (*types.SafeSuiBigInt[uint64], error) {
 	var resp types.SafeSuiBigInt[uint64]
 	return &resp, c.CallContext(ctx, &resp, getReferenceGasPrice)
 }
 
+// GetEpochs
+// cursor : the epoch to start after, start with the first epoch when cursor is nil
+// NOTE: the epochs are served by the nodes with the indexer only
+func (c *Client) GetEpochs(
+	ctx context.Context, cursor *string, limit *uint,
+	descendingOrder bool,
+) (*types.EpochPage, error) {
+	var resp types.EpochPage
+	return &resp, c.CallContext(ctx, &resp, getEpochs, cursor, limit, descendingOrder)
+}
+
+// GetReferenceGasPriceAtEpoch returns the reference gas price of the epoch, which is read from the `suix_getEpochs`
+// of the node, the current epoch is included.
+// NOTE: the epochs are served by the nodes with the indexer only, the plain fullnodes reject the method. The indexer
+// may have pruned the old epochs or not have recorded the gas price yet, these are returned as errors.
+func (c *Client) GetReferenceGasPriceAtEpoch(ctx context.Context, epoch uint64) (uint64, error) {
+	var cursor *string
+	if epoch > 0 {
+		previous := strconv.FormatUint(epoch-1, 10)
+		cursor = &previous
+	}
+	limit := uint(1)
+	page, err := c.GetEpochs(ctx, cursor, &limit, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(page.Data) == 0 || page.Data[0].Epoch.Uint64() != epoch {
+		return 0, fmt.Errorf("epoch %d is unavailable", epoch)
+	}
+	if page.Data[0].ReferenceGasPrice == nil {
+		return 0, fmt.Errorf("reference gas price of epoch %d is unavailable", epoch)
+	}
+	return page.Data[0].ReferenceGasPrice.Uint64(), nil
+}
+
+// GetEffectiveGasUnits returns the gas units of the computation cost in effects, see types.GasCostSummary.EffectiveGasUnits.
+// The reference gas price of a past epoch is read by GetReferenceGasPriceAtEpoch
+func (c *Client) GetEffectiveGasUnits(ctx context.Context, effects types.SuiTransactionBlockEffects) (uint64, error) {
+	if effects.V1 == nil {
+		return 0, errors.New("nil transaction effects")
+	}
+	state, err := c.GetLatestSuiSystemState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	executedEpoch := effects.V1.ExecutedEpoch.Uint64()
+	if state.Epoch.Uint64() == executedEpoch {
+		return effects.V1.GasUsed.EffectiveGasUnits(state.ReferenceGasPrice.Uint64()), nil
+	}
+	referenceGasPrice, err := c.GetReferenceGasPriceAtEpoch(ctx, executedEpoch)
+	if err != nil {
+		return 0, err
+	}
+	return effects.V1.GasUsed.EffectiveGasUnits(referenceGasPrice), nil
+}
+
 func (c *Client) GetEvents(ctx context.Context, digest suiDigest) ([]types.SuiEvent, error) {
 	var resp []types.SuiEvent
 	return resp, c.CallContext(ctx, &resp, getEvents, digest)
@@ -215,12 +707,165 @@ func (c *Client) DryRunTransaction(
 	return &resp, c.CallContext(ctx, &resp, dryRunTransactionBlock, txBytes)
 }
 
+// DryRunTransactionBytes dry runs the unsigned transaction returned by the unsafe_* methods, e.g. PayAllSui
+func (c *Client) DryRunTransactionBytes(
+	ctx context.Context,
+	tb *types.TransactionBytes,
+) (*types.DryRunTransactionBlockResponse, error) {
+	if tb == nil || len(tb.TxBytes) == 0 {
+		return nil, errors.New("empty transaction bytes")
+	}
+	return c.DryRunTransaction(ctx, tb.TxBytes)
+}
+
+// DryRunMaxGasBudget is the max gas budget of a transaction, used as the budget of dry run if it is not set
+const DryRunMaxGasBudget = 50_000_000_000
+
+// DryRunTransactionData dry runs the unsigned transaction data, the missing gas data is filled for the simulation:
+//   - the price is the reference gas price if it is 0
+//   - the payment is the SUI coin with the largest balance of the gas owner, or the sender if the owner is not set,
+//     excluding the coins which are inputs of the transaction
+//   - the budget is the balance of the payment, at most DryRunMaxGasBudget, if it is 0
+//
+// NOTE: the filled gas data is only for the simulation and tx is not modified, the gas of the transaction to sign
+// still needs to be chosen, e.g. by the `GasUsed` of the dry run
+func (c *Client) DryRunTransactionData(
+	ctx context.Context,
+	tx sui_types.TransactionData,
+) (*types.DryRunTransactionBlockResponse, error) {
+	if tx.V1 == nil {
+		return nil, errors.New("nil transaction data")
+	}
+	txV1 := *tx.V1
+	gasData := &txV1.GasData
+	if gasData.Owner == (suiAddress{}) {
+		gasData.Owner = txV1.Sender
+	}
+	if gasData.Price == 0 {
+		price, err := c.GetReferenceGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		gasData.Price = price.Uint64()
+	}
+	var balance uint64
+	if len(gasData.Payment) == 0 {
+		coin, err := c.pickDryRunGasCoin(ctx, gasData.Owner, txV1.Kind.ProgrammableTransaction)
+		if err != nil {
+			return nil, err
+		}
+		ref := coin.Reference()
+		gasData.Payment = []*sui_types.ObjectRef{ref}
+		balance = coin.Balance.Uint64()
+	}
+	if gasData.Budget == 0 {
+		if balance == 0 {
+			return nil, errors.New("gas budget is required if the gas payment is set")
+		}
+		gasData.Budget = balance
+		if gasData.Budget > DryRunMaxGasBudget {
+			gasData.Budget = DryRunMaxGasBudget
+		}
+	}
+	txBytes, err := sui_types.TransactionData{V1: &txV1}.TransactionBytes()
+	if err != nil {
+		return nil, err
+	}
+	return c.DryRunTransaction(ctx, txBytes)
+}
+
+func (c *Client) pickDryRunGasCoin(
+	ctx context.Context,
+	owner suiAddress,
+	pt *sui_types.ProgrammableTransaction,
+) (*types.Coin, error) {
+	inputs := make(map[suiObjectID]bool)
+	if pt != nil {
+		for _, input := range pt.Inputs {
+			if input.Object != nil && input.Object.ImmOrOwnedObject != nil {
+				inputs[input.Object.ImmOrOwnedObject.ObjectId] = true
+			}
+		}
+	}
+	coins, err := c.GetCoins(ctx, owner, nil, nil, QUERY_MAX_RESULT_LIMIT)
+	if err != nil {
+		return nil, err
+	}
+	var picked *types.Coin
+	for i, coin := range coins.Data {
+		if inputs[coin.CoinObjectId] {
+			continue
+		}
+		if picked == nil || coin.Balance.Cmp(picked.Balance) > 0 {
+			picked = &coins.Data[i]
+		}
+	}
+	if picked == nil {
+		return nil, fmt.Errorf("%w: no SUI coin of %v can pay the gas of dry run", types.ErrNoCoinsFound, owner)
+	}
+	return picked, nil
+}
+
+// ExecuteTransactionBlock the params are sent in the order of the node: the base64 txBytes, the array of base64
+// serialized signatures, the options object and the requestType string.
+// A signature can be a sui_types.Signature, the serialized bytes, or the base64 string.
+// options and requestType are sent as null if they are not set, so the node defaults are used
 func (c *Client) ExecuteTransactionBlock(
 	ctx context.Context, txBytes suiBase64Data, signatures []any,
 	options *types.SuiTransactionBlockResponseOptions, requestType types.ExecuteTransactionRequestType,
 ) (*types.SuiTransactionBlockResponse, error) {
+	encodedSignatures, err := encodeSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+	var reqType *types.ExecuteTransactionRequestType
+	if requestType != "" {
+		reqType = &requestType
+	}
 	resp := types.SuiTransactionBlockResponse{}
-	return &resp, c.CallContext(ctx, &resp, executeTransactionBlock, txBytes, signatures, options, requestType)
+	return &resp, c.CallContext(ctx, &resp, executeTransactionBlock, txBytes, encodedSignatures, options, reqType)
+}
+
+// encodeSignatures converts the signatures to base64 strings
+func encodeSignatures(signatures []any) ([]string, error) {
+	if len(signatures) == 0 {
+		return nil, errors.New("signatures is empty")
+	}
+	encoded := make([]string, len(signatures))
+	for i, signature := range signatures {
+		var data []byte
+		switch sig := signature.(type) {
+		case sui_types.Signature:
+			bytes, err := sig.Bytes()
+			if err != nil {
+				return nil, fmt.Errorf("invalid signature %d: %w", i, err)
+			}
+			data = bytes
+		case *sui_types.Signature:
+			bytes, err := sig.Bytes()
+			if err != nil {
+				return nil, fmt.Errorf("invalid signature %d: %w", i, err)
+			}
+			data = bytes
+		case []byte:
+			data = sig
+		case suiBase64Data:
+			data = sig
+		case string:
+			bytes, err := base64.StdEncoding.DecodeString(sig)
+			if err != nil {
+				return nil, fmt.Errorf("signature %d is not base64: %w", i, err)
+			}
+			data = bytes
+		default:
+			return nil, fmt.Errorf("unsupported type %T of signature %d", signature, i)
+		}
+		if len(data) == 0 {
+			return nil, fmt.Errorf("signature %d is empty", i)
+		}
+		encoded[i] = base64.StdEncoding.EncodeToString(data)
+	}
+	return encoded, nil
 }
 
 // TransferObject Create an unsigned transaction to transfer an object from one address to another. The object's type must allow public transfers
@@ -235,6 +880,31 @@ func (c *Client) TransferObject(
 	return &resp, c.CallContext(ctx, &resp, transferObject, signer, objID, gas, gasBudget, recipient)
 }
 
+// TransferObjectToZero Create an unsigned transaction to transfer an object to the address 0x0, nobody can use
+// the object afterwards, but it still exists on chain owned by 0x0.
+// The object must be owned by an address, shared and immutable objects are rejected.
+// NOTE: the storage rebate is not reclaimed, prefer a burn function of the package through MoveCall if there is one
+func (c *Client) TransferObjectToZero(
+	ctx context.Context,
+	signer suiAddress,
+	objID suiObjectID,
+	gas *suiObjectID,
+	gasBudget types.SafeSuiBigInt[uint64],
+) (*types.TransactionBytes, error) {
+	obj, err := c.GetObject(ctx, objID, &types.SuiObjectDataOptions{ShowOwner: true})
+	if err != nil {
+		return nil, err
+	}
+	if obj.Data == nil || obj.Data.Owner == nil {
+		return nil, fmt.Errorf("object %v not found", objID)
+	}
+	owner := obj.Data.Owner.ObjectOwnerInternal
+	if owner == nil || owner.AddressOwner == nil {
+		return nil, fmt.Errorf("object %v is not owned by an address", objID)
+	}
+	return c.TransferObject(ctx, signer, suiAddress{}, objID, gas, gasBudget)
+}
+
 // TransferSui Create an unsigned transaction to send SUI coin object to a Sui address. The SUI object is also used as the gas object.
 func (c *Client) TransferSui(
 	ctx context.Context, signer, recipient suiAddress, suiObjID suiObjectID, amount,
@@ -318,6 +988,9 @@ func (c *Client) MergeCoins(
 	return &resp, c.CallContext(ctx, &resp, mergeCoins, signer, primaryCoin, coinToMerge, gas, gasBudget)
 }
 
+// Publish Create an unsigned transaction to publish a Move package, see PublishModules.
+//
+// Deprecated: use PublishModules, which takes an optional gas object and the gas budget accepted by the node
 func (c *Client) Publish(
 	ctx context.Context,
 	sender suiAddress,
@@ -326,24 +999,70 @@ func (c *Client) Publish(
 	gas suiObjectID,
 	gasBudget uint,
 ) (*types.TransactionBytes, error) {
+	modules := make([][]byte, len(compiledModules))
+	for i, module := range compiledModules {
+		if module != nil {
+			modules[i] = module.Data()
+		}
+	}
+	return c.PublishModules(ctx, sender, modules, dependencies, &gas, types.NewSafeSuiBigInt(uint64(gasBudget)))
+}
+
+// PublishModules Create an unsigned transaction to publish a Move package.
+// compiledModules are the bytes of the modules as ModulePublish, and dependencies are the ids of all the packages
+// the modules depend on, including the framework packages `0x1` and `0x2`
+func (c *Client) PublishModules(
+	ctx context.Context,
+	sender suiAddress,
+	compiledModules [][]byte,
+	dependencies []suiObjectID,
+	gas *suiObjectID,
+	gasBudget types.SafeSuiBigInt[uint64],
+) (*types.TransactionBytes, error) {
+	if len(compiledModules) == 0 {
+		return nil, errors.New("compiled modules is empty")
+	}
+	modules := make([]suiBase64Data, len(compiledModules))
+	for i, module := range compiledModules {
+		if len(module) == 0 {
+			return nil, fmt.Errorf("compiled module %d is empty", i)
+		}
+		modules[i] = module
+	}
+	if dependencies == nil {
+		dependencies = []suiObjectID{}
+	}
 	var resp types.TransactionBytes
-	return &resp, c.CallContext(ctx, &resp, publish, sender, compiledModules, dependencies, gas, gasBudget)
+	return &resp, c.CallContext(ctx, &resp, publish, sender, modules, dependencies, gas, gasBudget)
 }
 
 // MoveCall Create an unsigned transaction to execute a Move call on the network, by calling the specified function in the module of a given package.
-// TODO: not support param `typeArguments` yet.
-// So now only methods with `typeArguments` are supported
+// The count of typeArgs is checked against the normalized function, which is fetched once per function and cached.
 // TODO: execution_mode : <SuiTransactionBlockBuilderMode>
 func (c *Client) MoveCall(
 	ctx context.Context,
 	signer suiAddress,
 	packageId suiObjectID,
 	module, function string,
-	typeArgs []string,
+	typeArgs []move_types.TypeTag,
 	arguments []any,
 	gas *suiObjectID,
 	gasBudget types.SafeSuiBigInt[uint64],
 ) (*types.TransactionBytes, error) {
+	if typeArgs == nil {
+		// the node expects an array
+		typeArgs = []move_types.TypeTag{}
+	}
+	typeParams, err := c.moveFunctionTypeParamCount(ctx, packageId, module, function)
+	if err != nil {
+		return nil, err
+	}
+	if typeParams >= 0 && typeParams != len(typeArgs) {
+		return nil, fmt.Errorf(
+			"%v::%v::%v expects %d type arguments, got %d", packageId.ShortString(), module, function,
+			typeParams, len(typeArgs),
+		)
+	}
 	resp := types.TransactionBytes{}
 	return &resp, c.CallContext(
 		ctx,
@@ -360,6 +1079,28 @@ func (c *Client) MoveCall(
 	)
 }
 
+// moveFunctionTypeParamCount returns the count of the type parameters of the function, -1 if the node can't find the
+// function, which is left to the node to report when building the transaction
+func (c *Client) moveFunctionTypeParamCount(
+	ctx context.Context,
+	packageId suiObjectID,
+	module, function string,
+) (int, error) {
+	key := packageId.String() + "::" + module + "::" + function
+	if count, ok := c.moveFunctionTypeParams.Load(key); ok {
+		return count.(int), nil
+	}
+	fn, err := c.GetNormalizedMoveFunction(ctx, packageId, module, function)
+	if err != nil {
+		if isMoveFunctionNotFound(err) {
+			return -1, nil
+		}
+		return 0, err
+	}
+	c.moveFunctionTypeParams.Store(key, len(fn.TypeParameters))
+	return len(fn.TypeParameters), nil
+}
+
 // TODO: execution_mode : <SuiTransactionBlockBuilderMode>
 func (c *Client) BatchTransaction(
 	ctx context.Context,
@@ -376,6 +1117,9 @@ func (c *Client) QueryTransactionBlocks(
 	ctx context.Context, query types.SuiTransactionBlockResponseQuery,
 	cursor *suiDigest, limit *uint, descendingOrder bool,
 ) (*types.TransactionBlocksPage, error) {
+	if err := checkLimitPtr(queryTransactionBlocks, limit, QUERY_MAX_RESULT_LIMIT); err != nil {
+		return nil, err
+	}
 	resp := types.TransactionBlocksPage{}
 	return &resp, c.CallContext(ctx, &resp, queryTransactionBlocks, query, cursor, limit, descendingOrder)
 }
@@ -384,6 +1128,9 @@ func (c *Client) QueryEvents(
 	ctx context.Context, query types.EventFilter, cursor *types.EventId, limit *uint,
 	descendingOrder bool,
 ) (*types.EventPage, error) {
+	if err := checkLimitPtr(queryEvents, limit, QUERY_MAX_RESULT_LIMIT); err != nil {
+		return nil, err
+	}
 	var resp types.EventPage
 	return &resp, c.CallContext(ctx, &resp, queryEvents, query, cursor, limit, descendingOrder)
 }
@@ -391,22 +1138,142 @@ func (c *Client) QueryEvents(
 func (c *Client) ResolveNameServiceAddress(ctx context.Context, suiName string) (*suiAddress, error) {
 	var resp suiAddress
 	err := c.CallContext(ctx, &resp, resolveNameServiceAddress, suiName)
-	if err != nil && err.Error() == "nil address" {
-		return nil, errors.New("sui name not found")
+	if err != nil {
+		if err.Error() == "nil address" {
+			return nil, errors.New("sui name not found")
+		}
+		return nil, err
 	}
 	return &resp, nil
 }
 
+// ResolveRecipient accepts a full hex address (0x followed by 64 hex digits) or a SuiNS name ending with `.sui`,
+// the name is resolved by the name service. Short addresses are rejected, since they are more likely typos
+func (c *Client) ResolveRecipient(ctx context.Context, recipient string) (*suiAddress, error) {
+	recipient = strings.TrimSpace(recipient)
+	switch {
+	case strings.HasSuffix(strings.ToLower(recipient), ".sui"):
+		for _, label := range strings.Split(recipient[:len(recipient)-len(".sui")], ".") {
+			if label == "" {
+				return nil, fmt.Errorf("invalid sui name %v", recipient)
+			}
+		}
+		return c.ResolveNameServiceAddress(ctx, recipient)
+	case strings.HasPrefix(recipient, "0x"):
+		if len(recipient) != 2+2*move_types.SuiAddressLen {
+			return nil, fmt.Errorf("invalid recipient address %v, expected 64 hex digits", recipient)
+		}
+		return sui_types.NewAddressFromHex(recipient)
+	default:
+		return nil, fmt.Errorf("invalid recipient %v, expected an address or a sui name", recipient)
+	}
+}
+
+type IdKind string
+
+const (
+	// IdKindObject the id is a live object, IdClassification.Object is set
+	IdKindObject IdKind = "object"
+	// IdKindPackage the id is a published package, IdClassification.Object is set
+	IdKindPackage IdKind = "package"
+	// IdKindDeletedObject the id was an object, but it has been deleted
+	IdKindDeletedObject IdKind = "deletedObject"
+	// IdKindProbablyAddress there is no object with the id, so it is probably an account address,
+	// it can also be an object which is wrapped, or an object not known to the node
+	IdKindProbablyAddress IdKind = "probablyAddress"
+)
+
+type IdClassification struct {
+	Id     suiAddress
+	Kind   IdKind
+	Object *types.SuiObjectData
+}
+
+// ClassifyId guesses whether the id is an object id or an account address. Both are 32 bytes and
+// indistinguishable by the format, so the id is looked up by getObject, and it is probably an address if there is
+// no such object. Short ids like `0x2` are accepted
+func (c *Client) ClassifyId(ctx context.Context, id string) (*IdClassification, error) {
+	id = strings.TrimSpace(id)
+	if !strings.HasPrefix(id, "0x") {
+		return nil, fmt.Errorf("invalid id %v, expected a 0x hex string", id)
+	}
+	addr, err := sui_types.NewAddressFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id %v: %w", id, err)
+	}
+	obj, err := c.GetObject(ctx, *addr, &types.SuiObjectDataOptions{ShowType: true, ShowOwner: true})
+	if err != nil {
+		return nil, err
+	}
+	classification := &IdClassification{Id: *addr}
+	switch {
+	case obj.Data != nil:
+		classification.Object = obj.Data
+		classification.Kind = IdKindObject
+		if obj.Data.Type != nil && *obj.Data.Type == "package" {
+			classification.Kind = IdKindPackage
+		}
+	case obj.Error != nil && obj.Error.Data.Deleted != nil:
+		classification.Kind = IdKindDeletedObject
+	default:
+		classification.Kind = IdKindProbablyAddress
+	}
+	return classification, nil
+}
+
+// TransferSuiToRecipient is TransferSui whose recipient is resolved by ResolveRecipient
+func (c *Client) TransferSuiToRecipient(
+	ctx context.Context, signer suiAddress, recipient string, suiObjID suiObjectID, amount,
+	gasBudget types.SafeSuiBigInt[uint64],
+) (*types.TransactionBytes, error) {
+	recipientAddr, err := c.ResolveRecipient(ctx, recipient)
+	if err != nil {
+		return nil, err
+	}
+	return c.TransferSui(ctx, signer, *recipientAddr, suiObjID, amount, gasBudget)
+}
+
+// TransferObjectToRecipient is TransferObject whose recipient is resolved by ResolveRecipient
+func (c *Client) TransferObjectToRecipient(
+	ctx context.Context,
+	signer suiAddress,
+	recipient string,
+	objID suiObjectID,
+	gas *suiObjectID,
+	gasBudget types.SafeSuiBigInt[uint64],
+) (*types.TransactionBytes, error) {
+	recipientAddr, err := c.ResolveRecipient(ctx, recipient)
+	if err != nil {
+		return nil, err
+	}
+	return c.TransferObject(ctx, signer, *recipientAddr, objID, gas, gasBudget)
+}
+
 func (c *Client) ResolveNameServiceNames(ctx context.Context,
 	owner suiAddress, cursor *suiObjectID, limit *uint) (*types.SuiNamePage, error) {
+	if err := checkLimitPtr(resolveNameServiceNames, limit, QUERY_MAX_RESULT_LIMIT); err != nil {
+		return nil, err
+	}
 	var resp types.SuiNamePage
 	return &resp, c.CallContext(ctx, &resp, resolveNameServiceNames, owner, cursor, limit)
 }
 
+func (c *Client) GetNormalizedMoveFunction(
+	ctx context.Context,
+	packageId suiObjectID,
+	module, function string,
+) (*types.SuiMoveNormalizedFunction, error) {
+	var resp types.SuiMoveNormalizedFunction
+	return &resp, c.CallContext(ctx, &resp, getNormalizedMoveFunction, packageId, module, function)
+}
+
 func (c *Client) GetDynamicFields(
 	ctx context.Context, parentObjectId suiObjectID, cursor *suiObjectID,
 	limit *uint,
 ) (*types.DynamicFieldPage, error) {
+	if err := checkLimitPtr(getDynamicFields, limit, QUERY_MAX_RESULT_LIMIT_OBJECTS); err != nil {
+		return nil, err
+	}
 	var resp types.DynamicFieldPage
 	return &resp, c.CallContext(ctx, &resp, getDynamicFields, parentObjectId, cursor, limit)
 }
@@ -5,12 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/move_types"
 	"github.com/coming-chat/go-sui/v2/sui_types"
 
 	"github.com/coming-chat/go-sui/v2/types"
+	"github.com/fardream/go-bcs/bcs"
 	"github.com/stretchr/testify/require"
 )
 
@@ -96,12 +101,27 @@ func TestClient_DryRunTransaction(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	resp, err := cli.DryRunTransaction(context.Background(), tx.TxBytes)
+	resp, err := cli.DryRunTransactionBytes(context.Background(), tx)
 	require.Nil(t, err)
 	t.Log("dry run status:", resp.Effects.Data.IsSuccess())
 	t.Log("dry run error:", resp.Effects.Data.V1.Status.Error)
 }
 
+func TestClient_DryRunTransactionData(t *testing.T) {
+	cli := ChainClient(t)
+	ptb := sui_types.NewProgrammableTransactionBuilder()
+	amount := SUI(0.01).Uint64()
+	err := ptb.TransferSui(*Address, &amount)
+	require.NoError(t, err)
+	tx := sui_types.NewProgrammable(*Address, nil, ptb.Finish(), 0, 0)
+
+	resp, err := cli.DryRunTransactionData(context.Background(), tx)
+	require.NoError(t, err)
+	require.True(t, resp.Effects.Data.IsSuccess())
+	require.Empty(t, tx.V1.GasData.Payment)
+	t.Log("gas used:", resp.Effects.Data.GasFee())
+}
+
 // TestClient_ExecuteTransactionSerializedSig
 // This test case will affect the real coin in the test case of account
 // temporary disabled
@@ -179,6 +199,23 @@ func TestClient_GetBalance(t *testing.T) {
 	)
 }
 
+func TestClient_GetOwnedCoinTypes(t *testing.T) {
+	chain := ChainClient(t)
+	coinTypes, err := chain.GetOwnedCoinTypes(context.TODO(), *Address, true)
+	require.NoError(t, err)
+	require.Equal(t, types.SuiCoinType, coinTypes[0])
+	t.Logf("coin types: %v", coinTypes)
+}
+
+func TestClient_GetSuiBalance(t *testing.T) {
+	chain := ChainClient(t)
+	balance, err := chain.GetSuiBalance(context.TODO(), *Address)
+	require.NoError(t, err)
+	formatted, err := chain.GetSuiBalanceFormatted(context.TODO(), *Address)
+	require.NoError(t, err)
+	t.Logf("SUI balance: %v MIST, %v SUI", balance.String(), formatted)
+}
+
 func TestClient_GetCoins(t *testing.T) {
 	chain := ChainClient(t)
 	defaultCoinType := types.SuiCoinType
@@ -355,6 +392,60 @@ func TestClient_MultiGetObjects(t *testing.T) {
 	require.Equal(t, resp[0], resp[1])
 }
 
+func TestClient_EstimateReclaimableRebate(t *testing.T) {
+	chain := ChainClient(t)
+	coins, err := chain.GetCoins(context.TODO(), *Address, nil, nil, 3)
+	require.NoError(t, err)
+	var objIds []suiObjectID
+	for _, coin := range coins.Data {
+		objIds = append(objIds, coin.CoinObjectId)
+	}
+	rebate, err := chain.EstimateReclaimableRebate(context.Background(), objIds)
+	require.NoError(t, err)
+	require.Greater(t, rebate, uint64(0))
+	t.Log("reclaimable rebate:", rebate)
+}
+
+func TestClient_GetSharedObjectInitialVersion(t *testing.T) {
+	chain := ChainClient(t)
+	version, err := chain.GetSharedObjectInitialVersion(context.Background(), *sui_types.SuiClockObjectId)
+	require.NoError(t, err)
+	require.Equal(t, sui_types.SuiClockObjectSharedVersion, version)
+
+	coins, err := chain.GetCoins(context.TODO(), *Address, nil, nil, 1)
+	require.NoError(t, err)
+	_, err = chain.GetSharedObjectInitialVersion(context.Background(), coins.Data[0].CoinObjectId)
+	require.ErrorIs(t, err, ErrObjectNotShared)
+}
+
+func TestClient_GetObjectDigest(t *testing.T) {
+	chain := ChainClient(t)
+	coins, err := chain.GetCoins(context.TODO(), *Address, nil, nil, 1)
+	require.NoError(t, err)
+	coin := coins.Data[0]
+
+	digest, version, err := chain.GetObjectDigest(context.Background(), coin.CoinObjectId)
+	require.NoError(t, err)
+	require.Equal(t, coin.Digest, digest)
+	require.Equal(t, coin.Version.Uint64(), version)
+}
+
+func TestClient_GetObjectBcs(t *testing.T) {
+	chain := ChainClient(t)
+	coins, err := chain.GetCoins(context.TODO(), *Address, nil, nil, 1)
+	require.NoError(t, err)
+	coin := coins.Data[0]
+
+	var decoded sui_types.Coin
+	data, err := chain.GetObjectBcs(context.Background(), coin.CoinObjectId, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, coin.CoinObjectId, decoded.Id)
+	require.Equal(t, coin.Balance.Uint64(), decoded.Balance)
+	encoded, err := bcs.Marshal(decoded)
+	require.NoError(t, err)
+	require.Equal(t, data.Bcs.Data.MoveObject.BcsBytes.Data(), encoded)
+}
+
 func TestClient_GetOwnedObjects(t *testing.T) {
 	cli := ChainClient(t)
 
@@ -425,6 +516,26 @@ func TestClient_GetLatestCheckpointSequenceNumber(t *testing.T) {
 	t.Log(res)
 }
 
+func TestClient_GetCheckpoint(t *testing.T) {
+	cli := MainnetClient(t)
+	seq, err := cli.GetLatestCheckpointSequenceNumber(context.Background())
+	require.Nil(t, err)
+	checkpoint, err := cli.GetCheckpoint(context.Background(), seq)
+	require.Nil(t, err)
+	require.Equal(t, seq, strconv.FormatUint(checkpoint.SequenceNumber.Uint64(), 10))
+	require.NotNil(t, checkpoint.PreviousDigest)
+	t.Log(checkpoint.Digest, checkpoint.Transactions)
+}
+
+func TestClient_GetCheckpoints(t *testing.T) {
+	cli := MainnetClient(t)
+	limit := uint(3)
+	page, err := cli.GetCheckpoints(context.Background(), nil, &limit, true)
+	require.Nil(t, err)
+	require.Len(t, page.Data, 3)
+	t.Log(page.NextCursor)
+}
+
 //func TestClient_Publish(t *testing.T) {
 //	chain := ChainClient(t)
 //	dmens, err := types.NewBase64Data(DmensDmensB64)
@@ -595,6 +706,54 @@ func TestClient_ResolveNameServiceAddress(t *testing.T) {
 	require.ErrorContains(t, err, "not found")
 }
 
+func TestClient_ResolveRecipient(t *testing.T) {
+	c := MainnetClient(t)
+	addr, err := c.ResolveRecipient(context.Background(), "2222.sui")
+	require.Nil(t, err)
+	require.Equal(t, addr.String(), "0x6174c5bd8ab9bf492e159a64e102de66429cfcde4fa883466db7b03af28b3ce9")
+
+	addr, err = c.ResolveRecipient(
+		context.Background(), "0x6174c5bd8ab9bf492e159a64e102de66429cfcde4fa883466db7b03af28b3ce9",
+	)
+	require.Nil(t, err)
+	require.Equal(t, addr.String(), "0x6174c5bd8ab9bf492e159a64e102de66429cfcde4fa883466db7b03af28b3ce9")
+
+	invalids := []string{
+		"0x2", "2222", ".sui", "a..sui", "0x6174c5bd8ab9bf492e159a64e102de66429cfcde4fa883466db7b03af28b3cez",
+	}
+	for _, invalid := range invalids {
+		_, err = c.ResolveRecipient(context.Background(), invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestClient_ClassifyId(t *testing.T) {
+	chain := ChainClient(t)
+	coins, err := chain.GetCoins(context.TODO(), *Address, nil, nil, 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		id   string
+		want IdKind
+	}{
+		{id: "0x2", want: IdKindPackage},
+		{id: coins.Data[0].CoinObjectId.String(), want: IdKindObject},
+		{id: Address.String(), want: IdKindProbablyAddress},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.id, func(t *testing.T) {
+				classification, err := chain.ClassifyId(context.Background(), tt.id)
+				require.NoError(t, err)
+				require.Equal(t, tt.want, classification.Kind)
+				require.Equal(t, tt.want != IdKindProbablyAddress, classification.Object != nil)
+			},
+		)
+	}
+	_, err = chain.ClassifyId(context.Background(), "2")
+	require.Error(t, err)
+}
+
 func TestClient_ResolveNameServiceNames(t *testing.T) {
 	c := MainnetClient(t)
 	owner := SuiAddressNoErr("0x57188743983628b3474648d8aa4a9ee8abebe8f6816243773d7e8ed4fd833a28")
@@ -699,6 +858,26 @@ func TestClient_GetDynamicFields(t *testing.T) {
 	}
 }
 
+func TestDeriveDynamicFieldObjectId(t *testing.T) {
+	chain := ChainClient(t)
+	parentObjectId, err := sui_types.NewAddressFromHex("0x1719957d7a2bf9d72459ff0eab8e600cbb1991ef41ddd5b4a8c531035933d256")
+	require.NoError(t, err)
+	limit := uint(5)
+	fields, err := chain.GetDynamicFields(context.TODO(), *parentObjectId, nil, &limit)
+	require.NoError(t, err)
+	for _, field := range fields.Data {
+		// the object id of a dynamic object field is the id of the child object rather than the field
+		if field.Type.Data.DynamicField == nil {
+			continue
+		}
+		keyType, err := move_types.ParseTypeTag(field.Name.Type)
+		require.NoError(t, err)
+		id, err := sui_types.DeriveDynamicFieldObjectId(*parentObjectId, *keyType, field.BcsName.Data())
+		require.NoError(t, err)
+		require.Equal(t, field.ObjectId, id)
+	}
+}
+
 func TestClient_GetDynamicFieldObject(t *testing.T) {
 	chain := ChainClient(t)
 	parentObjectId, err := sui_types.NewAddressFromHex("0x1719957d7a2bf9d72459ff0eab8e600cbb1991ef41ddd5b4a8c531035933d256")
@@ -739,3 +918,575 @@ func TestClient_GetDynamicFieldObject(t *testing.T) {
 		)
 	}
 }
+
+func TestClient_LimitExceeded(t *testing.T) {
+	// an oversized limit does not reach the node
+	cli := newFakeNode(t, nil)
+
+	ctx := context.Background()
+	objectsLimit := uint(QUERY_MAX_RESULT_LIMIT_OBJECTS + 1)
+	_, err := cli.GetOwnedObjects(ctx, sui_types.SuiAddress{}, nil, nil, &objectsLimit)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	_, err = cli.GetDynamicFields(ctx, sui_types.ObjectID{}, nil, &objectsLimit)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	_, err = cli.MultiGetObjects(ctx, make([]sui_types.ObjectID, objectsLimit), nil)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	_, err = cli.GetAllCoins(ctx, sui_types.SuiAddress{}, nil, QUERY_MAX_RESULT_LIMIT+1)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	checkpointsLimit := uint(QUERY_MAX_RESULT_LIMIT_CHECKPOINTS + 1)
+	_, err = cli.GetCheckpoints(ctx, nil, &checkpointsLimit, false)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestClient_GetCoinsWithBalance(t *testing.T) {
+	coinJson := func(id, balance string) string {
+		return `{"coinType":"0x2::sui::SUI","coinObjectId":"` + id + `","version":"1",` +
+			`"digest":"11111111111111111111111111111111","balance":"` + balance + `",` +
+			`"previousTransaction":"11111111111111111111111111111111"}`
+	}
+	var cursors []json.RawMessage
+	cli := newFakeNode(
+		t, map[string]any{
+			"suix_getCoins": func(params []json.RawMessage) any {
+				require.JSONEq(t, `"0x2::sui::SUI"`, string(params[1]))
+				cursors = append(cursors, params[2])
+				if len(cursors) == 1 {
+					return `{"data":[` + coinJson("0x1", "100") + `],"nextCursor":"0x1","hasNextPage":true}`
+				}
+				return `{"data":[` + coinJson("0x2", "18446744073709551615") + `],"nextCursor":"0x2","hasNextPage":false}`
+			},
+		},
+	)
+
+	coins, err := cli.GetCoinsWithBalance(context.Background(), sui_types.SuiAddress{}, "")
+	require.NoError(t, err)
+	require.Len(t, coins, 2)
+	require.Equal(t, uint64(100), coins[0].Balance.Uint64())
+	require.Equal(t, uint64(18446744073709551615), coins[1].Balance.Uint64())
+	require.Len(t, cursors, 2)
+	require.JSONEq(t, `null`, string(cursors[0]))
+	require.JSONEq(t, `"0x0000000000000000000000000000000000000000000000000000000000000001"`, string(cursors[1]))
+}
+
+func TestClient_GetOwnedObjectTypeCounts(t *testing.T) {
+	objectJson := func(id, objectType string) string {
+		return `{"data":{"objectId":"` + id + `","version":"1","digest":"11111111111111111111111111111111",` +
+			`"type":"` + objectType + `"}}`
+	}
+	var cursors []json.RawMessage
+	cli := newFakeNode(
+		t, map[string]any{
+			"suix_getOwnedObjects": func(params []json.RawMessage) any {
+				cursors = append(cursors, params[2])
+				if len(cursors) == 1 {
+					return `{"data":[` + objectJson("0x1", "0x2::coin::Coin<0x2::sui::SUI>") + `,` +
+						objectJson("0x2", "0x00000000000000000000000000000000000000000000000000000000000000ab::nft::Nft") +
+						`],"nextCursor":"0x2","hasNextPage":true}`
+				}
+				return `{"data":[` + objectJson("0x3", "0xab::nft::Nft") +
+					`,{"error":{"code":"deleted","object_id":"0x4"}}],"nextCursor":"0x4","hasNextPage":false}`
+			},
+		},
+	)
+
+	counts, err := cli.GetOwnedObjectTypeCounts(context.Background(), sui_types.SuiAddress{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"0x2::coin::Coin<0x2::sui::SUI>": 1, "0xab::nft::Nft": 2}, counts)
+	require.Len(t, cursors, 2)
+	require.JSONEq(t, `null`, string(cursors[0]))
+	require.JSONEq(t, `"0x0000000000000000000000000000000000000000000000000000000000000002"`, string(cursors[1]))
+}
+
+func TestClient_BatchGetFilteredObjectsOwnedByAddress(t *testing.T) {
+	objectJson := func(id, objectType string) string {
+		return `{"data":{"objectId":"` + id + `","version":"1","digest":"11111111111111111111111111111111",` +
+			`"type":"` + objectType + `"}}`
+	}
+	var cursors []json.RawMessage
+	var requestedIds []sui_types.ObjectID
+	cli := newFakeNode(
+		t, map[string]any{
+			"suix_getOwnedObjects": func(params []json.RawMessage) any {
+				cursors = append(cursors, params[2])
+				if len(cursors) == 1 {
+					return `{"data":[` + objectJson("0x1", "0xab::nft::Nft") + `],"nextCursor":"0x1","hasNextPage":true}`
+				}
+				return `{"data":[` + objectJson("0x2", "0xab::nft::Other") + `,` + objectJson("0x3", "0xab::nft::Nft") +
+					`],"nextCursor":"0x3","hasNextPage":false}`
+			},
+			"sui_multiGetObjects": func(params []json.RawMessage) any {
+				require.NoError(t, json.Unmarshal(params[0], &requestedIds))
+				return `[` + objectJson("0x1", "0xab::nft::Nft") + `,` + objectJson("0x3", "0xab::nft::Nft") + `]`
+			},
+		},
+	)
+
+	objs, err := cli.BatchGetObjectsOwnedByAddress(
+		context.Background(), sui_types.SuiAddress{}, types.SuiObjectDataOptions{}, "0xab::nft::Nft",
+	)
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	require.Equal(t, []sui_types.ObjectID{{31: 1}, {31: 3}}, requestedIds)
+	require.Len(t, cursors, 2)
+	require.JSONEq(t, `null`, string(cursors[0]))
+	// the cursor is the plain object id
+	require.JSONEq(t, `"0x0000000000000000000000000000000000000000000000000000000000000001"`, string(cursors[1]))
+}
+
+func TestClient_MoveCall_TypeArguments(t *testing.T) {
+	var lookups, moveCalls int
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_getNormalizedMoveFunction": func(params []json.RawMessage) any {
+				lookups++
+				switch string(params[2]) {
+				case `"swap"`:
+					return `{"visibility":"Public","isEntry":true,"typeParameters":[{"abilities":[]}],` +
+						`"parameters":[],"return":[]}`
+				case `"missing"`:
+					return &jsonError{Code: -32602, Message: "No function was found with function name missing"}
+				case `"unsupported"`:
+					return &jsonError{Code: -32601, Message: "Method not found"}
+				default:
+					return http.StatusServiceUnavailable
+				}
+			},
+			"unsafe_moveCall": func(params []json.RawMessage) any {
+				moveCalls++
+				return `{"txBytes":"AA==","gas":[],"inputObjects":[]}`
+			},
+		},
+	)
+
+	ctx := context.Background()
+	budget := types.NewSafeSuiBigInt(uint64(1000))
+	call := func(function string, typeArgs []move_types.TypeTag) error {
+		_, err := cli.MoveCall(
+			ctx, sui_types.SuiAddress{}, sui_types.ObjectID{2}, "pool", function, typeArgs, nil, nil, budget,
+		)
+		return err
+	}
+	sui := move_types.TypeTag{Address: &lib.EmptyEnum{}}
+	require.NoError(t, call("swap", []move_types.TypeTag{sui}))
+	require.ErrorContains(t, call("swap", nil), "expects 1 type arguments, got 0")
+	// the function is fetched once
+	require.Equal(t, 1, lookups)
+	require.Equal(t, 1, moveCalls)
+
+	// the missing function is left to the node
+	require.NoError(t, call("missing", nil))
+	require.Equal(t, 2, moveCalls)
+
+	var httpErr HTTPError
+	require.ErrorAs(t, call("unavailable", nil), &httpErr)
+	// the other errors of the lookup are surfaced
+	require.ErrorContains(t, call("unsupported", nil), "Method not found")
+	require.Equal(t, 2, moveCalls)
+}
+
+func TestClient_GetCoinMetadataByObjectId(t *testing.T) {
+	metadataJson := func(objectType, iconUrl string) string {
+		return `{"data":{"objectId":"0x1","version":"1","digest":"11111111111111111111111111111111",` +
+			`"content":{"dataType":"moveObject","type":"` + objectType + `","hasPublicTransfer":true,` +
+			`"fields":{"id":{"id":"0x1"},"decimals":6,"name":"USD Coin","symbol":"USDC","description":"usdc",` +
+			`"icon_url":` + iconUrl + `}}}}`
+	}
+	var response string
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_getObject": func(params []json.RawMessage) any {
+				return response
+			},
+		},
+	)
+	metadataId := sui_types.ObjectID{31: 1}
+
+	response = metadataJson("0x2::coin::CoinMetadata<0xab::usdc::USDC>", `"https://usdc.png"`)
+	metadata, err := cli.GetCoinMetadataByObjectId(context.Background(), metadataId)
+	require.NoError(t, err)
+	require.Equal(
+		t, types.SuiCoinMetadata{
+			Decimals:    6,
+			Description: "usdc",
+			IconUrl:     "https://usdc.png",
+			Id:          metadataId,
+			Name:        "USD Coin",
+			Symbol:      "USDC",
+		}, *metadata,
+	)
+
+	response = metadataJson(
+		"0x2::coin::CoinMetadata<0xab::usdc::USDC>",
+		`{"type":"0x1::option::Option<0x2::url::Url>","fields":{"vec":[{"type":"0x2::url::Url","fields":{"url":"https://usdc.png"}}]}}`,
+	)
+	metadata, err = cli.GetCoinMetadataByObjectId(context.Background(), metadataId)
+	require.NoError(t, err)
+	require.Equal(t, "https://usdc.png", metadata.IconUrl)
+
+	response = metadataJson("0x2::coin::CoinMetadata<0xab::usdc::USDC>", `null`)
+	metadata, err = cli.GetCoinMetadataByObjectId(context.Background(), metadataId)
+	require.NoError(t, err)
+	require.Empty(t, metadata.IconUrl)
+
+	response = metadataJson("0x2::coin::TreasuryCap<0xab::usdc::USDC>", `null`)
+	_, err = cli.GetCoinMetadataByObjectId(context.Background(), metadataId)
+	require.ErrorIs(t, err, ErrNotCoinMetadata)
+}
+
+func TestClient_GetCoinBalance(t *testing.T) {
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_getObject": func(params []json.RawMessage) any {
+				var id string
+				require.NoError(t, json.Unmarshal(params[0], &id))
+				objectType := "0x2::coin::Coin<0x2::sui::SUI>"
+				if id != "0x0000000000000000000000000000000000000000000000000000000000000001" {
+					objectType = "0x2::coin::TreasuryCap<0x2::sui::SUI>"
+				}
+				return `{"data":{"objectId":"` + id + `","version":"1","digest":"11111111111111111111111111111111",` +
+					`"content":{"dataType":"moveObject","type":"` + objectType + `","hasPublicTransfer":true,` +
+					`"fields":{"id":{"id":"` + id + `"},"balance":"18446744073709551615"}}}}`
+			},
+		},
+	)
+
+	balance, err := cli.GetCoinBalance(context.Background(), sui_types.ObjectID{31: 1})
+	require.NoError(t, err)
+	require.Equal(t, "18446744073709551615", balance.String())
+
+	_, err = cli.GetCoinBalance(context.Background(), sui_types.ObjectID{31: 2})
+	require.ErrorIs(t, err, ErrNotCoin)
+}
+
+func TestClient_PreflightPayment(t *testing.T) {
+	const usdc = "0xa::usdc::USDC"
+	coins := map[string][]string{
+		types.SuiCoinType: {"400", "1100"},
+		usdc:              {"60", "40"},
+	}
+	balancesOf := func(params []json.RawMessage) (string, []string) {
+		var coinType string
+		require.NoError(t, json.Unmarshal(params[1], &coinType))
+		return coinType, coins[coinType]
+	}
+	cli := newFakeNode(
+		t, map[string]any{
+			"suix_getBalance": func(params []json.RawMessage) any {
+				coinType, balances := balancesOf(params)
+				total := 0
+				for _, balance := range balances {
+					n, err := strconv.Atoi(balance)
+					require.NoError(t, err)
+					total += n
+				}
+				return `{"coinType":"` + coinType + `","coinObjectCount":` + strconv.Itoa(len(balances)) + `,` +
+					`"totalBalance":"` + strconv.Itoa(total) + `","lockedBalance":{}}`
+			},
+			"suix_getCoins": func(params []json.RawMessage) any {
+				coinType, balances := balancesOf(params)
+				var data []string
+				for i, balance := range balances {
+					data = append(
+						data, `{"coinType":"`+coinType+`","coinObjectId":"0x`+strconv.Itoa(len(coinType)*10+i)+`",`+
+							`"version":"1","digest":"11111111111111111111111111111111","balance":"`+balance+`",`+
+							`"previousTransaction":"11111111111111111111111111111111"}`,
+					)
+				}
+				return `{"data":[` + strings.Join(data, ",") + `],"nextCursor":null,"hasNextPage":false}`
+			},
+		},
+	)
+	ctx := context.Background()
+
+	require.NoError(t, cli.PreflightPayment(ctx, sui_types.SuiAddress{}, usdc, 100, 1000))
+	require.NoError(t, cli.PreflightPayment(ctx, sui_types.SuiAddress{}, "", 500, 1000))
+
+	tests := []struct {
+		name      string
+		coinType  string
+		amount    uint64
+		gasBudget uint64
+		wantType  string
+		shortfall int64
+	}{
+		{name: "not enough coin", coinType: usdc, amount: 150, gasBudget: 1000, wantType: usdc, shortfall: 50},
+		{name: "not enough gas", coinType: usdc, amount: 100, gasBudget: 2000, wantType: types.SuiCoinType, shortfall: 500},
+		{name: "not enough sui", coinType: "", amount: 600, gasBudget: 1000, wantType: types.SuiCoinType, shortfall: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := cli.PreflightPayment(ctx, sui_types.SuiAddress{}, tt.coinType, tt.amount, tt.gasBudget)
+			require.ErrorIs(t, err, types.ErrInsufficientBalance)
+			var balanceErr *InsufficientBalanceError
+			require.ErrorAs(t, err, &balanceErr)
+			require.Equal(t, tt.wantType, balanceErr.CoinType)
+			require.Equal(t, tt.shortfall, balanceErr.Shortfall().IntPart())
+		})
+	}
+}
+
+func TestClient_GetLoadedChildObjects(t *testing.T) {
+	supported := true
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_getLoadedChildObjects": func(params []json.RawMessage) any {
+				if !supported {
+					return &jsonError{Code: -32601, Message: "Method not found"}
+				}
+				return `{"loadedChildObjects":[{"objectId":"0x11","sequenceNumber":"7"}]}`
+			},
+		},
+	)
+
+	digest, err := sui_types.NewDigest("HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn")
+	require.NoError(t, err)
+	resp, err := cli.GetLoadedChildObjects(context.Background(), *digest)
+	require.NoError(t, err)
+	require.Len(t, resp.LoadedChildObjects, 1)
+	require.Equal(t, sui_types.ObjectID{31: 0x11}, resp.LoadedChildObjects[0].ObjectId)
+	require.Equal(t, uint64(7), resp.LoadedChildObjects[0].SequenceNumber.Uint64())
+
+	supported = false
+	_, err = cli.GetLoadedChildObjects(context.Background(), *digest)
+	require.ErrorIs(t, err, ErrMethodNotSupported)
+}
+
+func TestClient_GetObjectDigestDeleted(t *testing.T) {
+	var lastParams []json.RawMessage
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_getObject": func(params []json.RawMessage) any {
+				lastParams = params
+				if string(params[0]) == `"0x0000000000000000000000000000000000000000000000000000000000000001"` {
+					return `{"data":{"objectId":"0x1","version":"12","digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}}`
+				}
+				return `{"error":{"code":"deleted","object_id":"0x2","version":13,` +
+					`"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}}`
+			},
+		},
+	)
+
+	digest, version, err := cli.GetObjectDigest(context.Background(), sui_types.ObjectID{31: 1})
+	require.NoError(t, err)
+	require.Equal(t, "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn", digest.String())
+	require.Equal(t, uint64(12), version)
+	require.JSONEq(t, `null`, string(lastParams[1]))
+
+	_, version, err = cli.GetObjectDigest(context.Background(), sui_types.ObjectID{31: 2})
+	require.ErrorIs(t, err, ErrObjectDeleted)
+	require.Equal(t, uint64(13), version)
+}
+
+// epochsPageJson the page of suix_getEpochs holding the epoch 10, whose reference gas price is 750
+const epochsPageJson = `{"data":[{"epoch":"10","validators":[],"epochTotalTransactions":"0",` +
+	`"firstCheckpointId":"100","epochStartTimestamp":"0","endOfEpochInfo":null,"referenceGasPrice":"750"}],` +
+	`"nextCursor":"10","hasNextPage":true}`
+
+func TestClient_GetReferenceGasPriceAtEpoch(t *testing.T) {
+	var lastParams []json.RawMessage
+	cli := newFakeNode(
+		t, map[string]any{
+			"suix_getEpochs": func(params []json.RawMessage) any {
+				lastParams = params
+				if string(params[0]) == `"9"` {
+					return epochsPageJson
+				}
+				return `{"data":[],"hasNextPage":false}`
+			},
+		},
+	)
+
+	price, err := cli.GetReferenceGasPriceAtEpoch(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(750), price)
+	require.JSONEq(
+		t, `["9",1,false]`, "["+string(lastParams[0])+","+string(lastParams[1])+","+string(lastParams[2])+"]",
+	)
+
+	_, err = cli.GetReferenceGasPriceAtEpoch(context.Background(), 0)
+	require.Error(t, err)
+	require.JSONEq(t, `null`, string(lastParams[0]))
+}
+
+func TestClient_GetEffectiveGasUnits(t *testing.T) {
+	var methods []string
+	cli := newFakeNode(
+		t, map[string]any{
+			"suix_getLatestSuiSystemState": func(params []json.RawMessage) any {
+				methods = append(methods, "suix_getLatestSuiSystemState")
+				return `{"epoch":"12","referenceGasPrice":"1000"}`
+			},
+			"suix_getEpochs": func(params []json.RawMessage) any {
+				methods = append(methods, "suix_getEpochs")
+				require.Equal(t, `"9"`, string(params[0]))
+				return epochsPageJson
+			},
+		},
+	)
+
+	effects := func(epoch uint64) types.SuiTransactionBlockEffects {
+		return types.SuiTransactionBlockEffects{
+			V1: &types.SuiTransactionBlockEffectsV1{
+				ExecutedEpoch: types.NewSafeSuiBigInt(epoch),
+				GasUsed:       types.GasCostSummary{ComputationCost: types.NewSafeSuiBigInt(uint64(1_500_000))},
+			},
+		}
+	}
+	units, err := cli.GetEffectiveGasUnits(context.Background(), effects(12))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1500), units)
+	require.Equal(t, []string{"suix_getLatestSuiSystemState"}, methods)
+
+	// the reference gas price of the past epoch
+	methods = nil
+	units, err = cli.GetEffectiveGasUnits(context.Background(), effects(10))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2000), units)
+	require.Equal(t, []string{"suix_getLatestSuiSystemState", "suix_getEpochs"}, methods)
+}
+
+func TestClient_DryRunTransactionDataBytes(t *testing.T) {
+	var dryRunTx sui_types.TransactionData
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_dryRunTransactionBlock": func(params []json.RawMessage) any {
+				require.Len(t, params, 1)
+				var txBytes lib.Base64Data
+				require.NoError(t, json.Unmarshal(params[0], &txBytes))
+				n, err := bcs.Unmarshal(txBytes.Data(), &dryRunTx)
+				require.NoError(t, err)
+				require.Equal(t, len(txBytes.Data()), n)
+				return `{"effects":{"messageVersion":"v1","status":{"status":"success"}},` +
+					`"events":[],"objectChanges":[],"balanceChanges":[],"input":{"messageVersion":"v1"}}`
+			},
+		},
+	)
+
+	ptb := sui_types.NewProgrammableTransactionBuilder()
+	amount := uint64(100)
+	require.NoError(t, ptb.TransferSui(sui_types.SuiAddress{1}, &amount))
+	pt := ptb.Finish()
+	digest, err := sui_types.NewDigest("HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn")
+	require.NoError(t, err)
+	gas := []*sui_types.ObjectRef{{ObjectId: sui_types.ObjectID{2}, Version: 3, Digest: *digest}}
+	// the transaction data built by hand without the expiration
+	tx := sui_types.TransactionData{
+		V1: &sui_types.TransactionDataV1{
+			Kind:    sui_types.TransactionKind{ProgrammableTransaction: &pt},
+			Sender:  sui_types.SuiAddress{1},
+			GasData: sui_types.GasData{Payment: gas, Owner: sui_types.SuiAddress{1}, Price: 1000, Budget: 5000000},
+		},
+	}
+
+	resp, err := cli.DryRunTransactionData(context.Background(), tx)
+	require.NoError(t, err)
+	require.True(t, resp.Effects.Data.IsSuccess())
+	require.NotNil(t, dryRunTx.V1)
+	require.Equal(t, tx.V1.Sender, dryRunTx.V1.Sender)
+	require.Equal(t, tx.V1.GasData, dryRunTx.V1.GasData)
+	require.NotNil(t, dryRunTx.V1.Expiration.None)
+	require.Equal(t, pt, *dryRunTx.V1.Kind.ProgrammableTransaction)
+}
+
+func TestClient_PublishParams(t *testing.T) {
+	var lastParams []json.RawMessage
+	cli := newFakeNode(
+		t, map[string]any{
+			"unsafe_publish": func(params []json.RawMessage) any {
+				lastParams = params
+				return `{"gas":[],"inputObjects":[],"txBytes":"AAE="}`
+			},
+		},
+	)
+
+	ctx := context.Background()
+	_, err := cli.PublishModules(ctx, sui_types.SuiAddress{}, nil, nil, nil, types.NewSafeSuiBigInt(uint64(1000)))
+	require.Error(t, err)
+	_, err = cli.PublishModules(ctx, sui_types.SuiAddress{}, [][]byte{{}}, nil, nil, types.NewSafeSuiBigInt(uint64(1000)))
+	require.Error(t, err)
+	require.Nil(t, lastParams)
+
+	resp, err := cli.PublishModules(
+		ctx, sui_types.SuiAddress{}, [][]byte{{1, 2, 3}}, []sui_types.ObjectID{{1}, {2}}, nil,
+		types.NewSafeSuiBigInt(uint64(1000)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 1}, resp.TxBytes.Data())
+	require.Len(t, lastParams, 5)
+	require.JSONEq(t, `["AQID"]`, string(lastParams[1]))
+	require.JSONEq(t, `null`, string(lastParams[3]))
+	require.JSONEq(t, `"1000"`, string(lastParams[4]))
+	var deps []sui_types.ObjectID
+	require.NoError(t, json.Unmarshal(lastParams[2], &deps))
+	require.Equal(t, []sui_types.ObjectID{{1}, {2}}, deps)
+
+	// the legacy signature
+	module := suiBase64Data{4, 5, 6}
+	_, err = cli.Publish(ctx, sui_types.SuiAddress{}, []*suiBase64Data{&module}, nil, sui_types.ObjectID{3}, 1000)
+	require.NoError(t, err)
+	require.JSONEq(t, `["BAUG"]`, string(lastParams[1]))
+	require.JSONEq(t, `"`+sui_types.ObjectID{3}.String()+`"`, string(lastParams[3]))
+	require.JSONEq(t, `"1000"`, string(lastParams[4]))
+	_, err = cli.Publish(ctx, sui_types.SuiAddress{}, []*suiBase64Data{nil}, nil, sui_types.ObjectID{3}, 1000)
+	require.Error(t, err)
+}
+
+// executeTransactionBlockRequest is the request expected by the node, the signature is signed by the ed25519 key
+// of the zero seed
+const executeTransactionBlockRequest = `{"jsonrpc":"2.0","id":1,"method":"sui_executeTransactionBlock","params":[` +
+	`"AAACAAgA4fUFAAAAAAAg",` +
+	`["AIaUWyyAdVfz0rR2YcHV5t+Nex/s6qk9ScH5P+4tEiRxpGHZFKR7qm46LGMdT1yDcraccOlDBCboJe2R0KSthwo7aie8zrakLWKjqNAqbw1zZTIVdx3iQ6Y6wEihi1naKQ=="],` +
+	`{"showInput":true,"showEffects":true},"WaitForLocalExecution"]}`
+
+func TestClient_ExecuteTransactionBlockParams(t *testing.T) {
+	var recorded struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(executeTransactionBlockRequest), &recorded))
+
+	var lastParams []json.RawMessage
+	cli := newFakeNode(
+		t, map[string]any{
+			recorded.Method: func(params []json.RawMessage) any {
+				lastParams = params
+				return `{"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}`
+			},
+		},
+	)
+
+	txBytes, err := lib.NewBase64Data("AAACAAgA4fUFAAAAAAAg")
+	require.NoError(t, err)
+	var sigStrings []string
+	require.NoError(t, json.Unmarshal(recorded.Params[1], &sigStrings))
+	sigString := sigStrings[0]
+	sig, err := sui_types.NewSignatureFromBase64(sigString)
+	require.NoError(t, err)
+	keyPair := sui_types.NewSuiKeyPair(sui_types.SignatureScheme{ED25519: &lib.EmptyEnum{}}, make([]byte, 32))
+	signed, err := keyPair.SignTransaction(*txBytes)
+	require.NoError(t, err)
+	require.Equal(t, sigString, signed)
+	options := &types.SuiTransactionBlockResponseOptions{ShowInput: true, ShowEffects: true}
+
+	for _, signature := range []any{sigString, sig, &sig} {
+		_, err = cli.ExecuteTransactionBlock(
+			context.Background(), *txBytes, []any{signature}, options, types.TxnRequestTypeWaitForLocalExecution,
+		)
+		require.NoError(t, err)
+		require.Len(t, lastParams, len(recorded.Params))
+		require.JSONEq(t, string(recorded.Params[0]), string(lastParams[0]))
+		require.JSONEq(t, string(recorded.Params[1]), string(lastParams[1]))
+		require.JSONEq(t, string(recorded.Params[2]), string(lastParams[2]))
+		require.JSONEq(t, string(recorded.Params[3]), string(lastParams[3]))
+	}
+
+	_, err = cli.ExecuteTransactionBlock(context.Background(), *txBytes, []any{sigString}, nil, "")
+	require.NoError(t, err)
+	require.JSONEq(t, `null`, string(lastParams[2]))
+	require.JSONEq(t, `null`, string(lastParams[3]))
+
+	_, err = cli.ExecuteTransactionBlock(context.Background(), *txBytes, []any{1}, nil, "")
+	require.Error(t, err)
+	_, err = cli.ExecuteTransactionBlock(context.Background(), *txBytes, nil, nil, "")
+	require.Error(t, err)
+}
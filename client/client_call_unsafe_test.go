@@ -10,6 +10,7 @@ import (
 
 	"github.com/coming-chat/go-sui/v2/account"
 	"github.com/coming-chat/go-sui/v2/types"
+	"github.com/fardream/go-bcs/bcs"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,6 +55,51 @@ func TestClient_TransferSui(t *testing.T) {
 	simulateCheck(t, cli, txn.TxBytes, true)
 }
 
+// TestTransactionBytes_RoundTrip decodes the bcs bytes produced by the node, and checks them against the json input
+// of the dry run
+func TestTransactionBytes_RoundTrip(t *testing.T) {
+	cli := ChainClient(t)
+	signer := M1Address(t)
+	coins, err := cli.GetCoins(context.Background(), *signer, nil, nil, 10)
+	require.NoError(t, err)
+	amount := SUI(0.0001).Uint64()
+	gasBudget := SUI(0.01).Uint64()
+	pickedCoins, err := types.PickupCoins(coins, *big.NewInt(0).SetUint64(amount), gasBudget, 1, 0)
+	require.Nil(t, err)
+	txn, err := cli.TransferSui(
+		context.Background(), *signer, *signer,
+		pickedCoins.Coins[0].CoinObjectId,
+		types.NewSafeSuiBigInt(amount),
+		types.NewSafeSuiBigInt(gasBudget),
+	)
+	require.Nil(t, err)
+
+	var tx sui_types.TransactionData
+	_, err = bcs.Unmarshal(txn.TxBytes, &tx)
+	require.NoError(t, err)
+	txBytes, err := bcs.Marshal(tx)
+	require.NoError(t, err)
+	require.Equal(t, []byte(txn.TxBytes), txBytes)
+
+	simulate, err := cli.DryRunTransaction(context.Background(), txn.TxBytes)
+	require.Nil(t, err)
+	input := simulate.Input.Data.V1
+	require.NotNil(t, input)
+	require.Equal(t, tx.V1.Sender, input.Sender)
+	gasOwner, err := input.GasData.OwnerAddress()
+	require.NoError(t, err)
+	require.Equal(t, tx.V1.GasData.Owner, *gasOwner)
+	require.Equal(t, tx.V1.GasData.Price, input.GasData.Price.Uint64())
+	require.Equal(t, tx.V1.GasData.Budget, input.GasData.Budget.Uint64())
+	require.Len(t, input.GasData.Payment, len(tx.V1.GasData.Payment))
+	for i, payment := range input.GasData.Payment {
+		ref, err := payment.ObjectRef()
+		require.NoError(t, err)
+		require.Equal(t, tx.V1.GasData.Payment[i], ref)
+	}
+	require.Equal(t, txn.Gas[0], *tx.V1.GasData.Payment[0])
+}
+
 func TestClient_PayAllSui(t *testing.T) {
 	cli := ChainClient(t)
 	signer := M1Address(t)
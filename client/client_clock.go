@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// GetClockTimestampMs returns the `timestamp_ms` of the shared Clock object `0x6`.
+// NOTE: the clock is updated by the consensus commit prologue, so it reflects the time of the last checkpoint
+// rather than the wall clock
+func (c *Client) GetClockTimestampMs(ctx context.Context) (uint64, error) {
+	resp, err := c.GetObject(ctx, *sui_types.SuiClockObjectId, &types.SuiObjectDataOptions{ShowContent: true})
+	if err != nil {
+		return 0, err
+	}
+	if resp.Data == nil || resp.Data.Content == nil || resp.Data.Content.Data.MoveObject == nil {
+		return 0, errors.New("invalid clock object")
+	}
+	var fields struct {
+		TimestampMs *types.SafeSuiBigInt[uint64] `json:"timestamp_ms"`
+	}
+	err = resp.Data.Content.Data.MoveObject.DecodeFields(&fields)
+	if err != nil {
+		return 0, err
+	}
+	if fields.TimestampMs == nil {
+		return 0, errors.New("clock object has no timestamp_ms")
+	}
+	return fields.TimestampMs.Uint64(), nil
+}
@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetClockTimestampMs(t *testing.T) {
+	cli := MainnetClient(t)
+	timestampMs, err := cli.GetClockTimestampMs(context.Background())
+	require.Nil(t, err)
+	require.Less(t, timestampMs, uint64(time.Now().Add(time.Minute).UnixMilli()))
+	t.Logf("clock timestamp = %v", time.UnixMilli(int64(timestampMs)))
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// GasEstimate the dry run gas of a transaction
+type GasEstimate struct {
+	GasUsed types.GasCostSummary
+	// Fee the net cost, which is computation cost + storage cost - storage rebate, negative if the rebate is larger
+	Fee int64
+	// Budget the min gas budget, which is computation cost + storage cost since the rebate is refunded after charging
+	Budget uint64
+	// Err the dry run failed, or the transaction failed in the dry run
+	Err error
+}
+
+type BatchGasEstimate struct {
+	// Estimates of the transactions, at the same index
+	Estimates []GasEstimate
+	// TotalFee the sum of the fees of the transactions
+	TotalFee int64
+	// TotalBudget the sum of the budgets of the transactions, which is the SUI needed to execute all of them
+	TotalBudget uint64
+}
+
+// EstimateBatchGas dry runs the transactions with at most `parallelism` concurrent requests by DryRunTransactionData,
+// so the missing gas data of the transactions is filled for the simulation.
+// The totals only include the successful estimates, the first failure is returned with the estimates as
+// an error of its index, so all the failures can still be checked in the estimates.
+// NOTE: the transactions are dry run independently against the current state, so the changes of the earlier
+// transactions, e.g. the spent gas coin, are not seen by the later ones
+func (c *Client) EstimateBatchGas(
+	ctx context.Context,
+	txs []sui_types.TransactionData,
+	parallelism int,
+) (*BatchGasEstimate, error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	result := &BatchGasEstimate{Estimates: make([]GasEstimate, len(txs))}
+	var wg sync.WaitGroup
+	indexes := make(chan int)
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result.Estimates[i] = c.estimateGas(ctx, txs[i])
+			}
+		}()
+	}
+	for i := range txs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var firstErr error
+	for i, estimate := range result.Estimates {
+		if estimate.Err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("transaction %d: %w", i, estimate.Err)
+			}
+			continue
+		}
+		result.TotalFee += estimate.Fee
+		result.TotalBudget += estimate.Budget
+	}
+	return result, firstErr
+}
+
+func (c *Client) estimateGas(ctx context.Context, tx sui_types.TransactionData) GasEstimate {
+	if err := ctx.Err(); err != nil {
+		return GasEstimate{Err: err}
+	}
+	resp, err := c.DryRunTransactionData(ctx, tx)
+	if err != nil {
+		return GasEstimate{Err: err}
+	}
+	effects := resp.Effects.Data
+	if effects.V1 == nil {
+		return GasEstimate{Err: errors.New("no effects in the dry run")}
+	}
+	if !effects.IsSuccess() {
+		return GasEstimate{GasUsed: effects.V1.GasUsed, Err: fmt.Errorf("dry run failed: %v", effects.V1.Status.Error)}
+	}
+	gasUsed := effects.V1.GasUsed
+	return GasEstimate{
+		GasUsed: gasUsed,
+		Fee:     effects.GasFee(),
+		Budget:  gasUsed.ComputationCost.Uint64() + gasUsed.StorageCost.Uint64(),
+	}
+}
+
+// CommandGasCost the gas attributed to the commands [FromCommand, ToCommand] of a programmable transaction,
+// the costs are signed since a later command may delete the objects created by the earlier ones
+type CommandGasCost struct {
+	FromCommand     int
+	ToCommand       int
+	ComputationCost int64
+	StorageCost     int64
+	StorageRebate   int64
+}
+
+// EstimateCommandGas attributes the gas of the programmable transaction to its commands by devInspect.
+// The node only reports the gas of the whole transaction, so the transaction is inspected with the first 1..n commands,
+// and the gas of a command is the difference between the prefix with it and the prefix without it.
+// A prefix that fails, e.g. a result without drop ability isn't used until a later command, can't be measured,
+// so its commands are grouped with the next measurable one in the same CommandGasCost.
+// NOTE: the attribution is an approximation of the node-provided totals:
+//   - the computation cost is charged by buckets, so cheap commands may be attributed 0 and the bucket boundary
+//     to a single command
+//   - the storage cost and rebate are computed on the final objects, so a command mutating an object created earlier
+//     is attributed the difference of the storage, not the storage it touches
+//   - every prefix is inspected against the current state, so the n requests are not atomic
+func (c *Client) EstimateCommandGas(
+	ctx context.Context,
+	sender suiAddress,
+	pt sui_types.ProgrammableTransaction,
+	gasPrice *types.SafeSuiBigInt[uint64],
+) ([]CommandGasCost, error) {
+	if len(pt.Commands) == 0 {
+		return nil, errors.New("no commands to estimate")
+	}
+	var (
+		costs []CommandGasCost
+		prev  types.GasCostSummary
+		from  int
+	)
+	for i := range pt.Commands {
+		prefix := sui_types.ProgrammableTransaction{Inputs: pt.Inputs, Commands: pt.Commands[:i+1]}
+		gasUsed, err := c.inspectGasUsed(ctx, sender, prefix, gasPrice)
+		if err != nil {
+			if i < len(pt.Commands)-1 {
+				continue
+			}
+			return costs, fmt.Errorf("command %d: %w", i, err)
+		}
+		costs = append(
+			costs, CommandGasCost{
+				FromCommand:     from,
+				ToCommand:       i,
+				ComputationCost: gasUsed.ComputationCost.Int64() - prev.ComputationCost.Int64(),
+				StorageCost:     gasUsed.StorageCost.Int64() - prev.StorageCost.Int64(),
+				StorageRebate:   gasUsed.StorageRebate.Int64() - prev.StorageRebate.Int64(),
+			},
+		)
+		prev = *gasUsed
+		from = i + 1
+	}
+	return costs, nil
+}
+
+func (c *Client) inspectGasUsed(
+	ctx context.Context,
+	sender suiAddress,
+	pt sui_types.ProgrammableTransaction,
+	gasPrice *types.SafeSuiBigInt[uint64],
+) (*types.GasCostSummary, error) {
+	kindBytes, err := sui_types.NewProgrammableWithoutGas(sender, pt).KindBytes()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.DevInspectTransactionBlock(ctx, sender, kindBytes, gasPrice, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, errors.New(*resp.Error)
+	}
+	effects := resp.Effects.Data
+	if effects.V1 == nil {
+		return nil, errors.New("no effects in the dev inspect")
+	}
+	if !effects.IsSuccess() {
+		return nil, fmt.Errorf("dev inspect failed: %v", effects.V1.Status.Error)
+	}
+	return &effects.V1.GasUsed, nil
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/fardream/go-bcs/bcs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_EstimateBatchGas(t *testing.T) {
+	var running, maxRunning int32
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_dryRunTransactionBlock": func(params []json.RawMessage) any {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+
+				var txBytes lib.Base64Data
+				require.NoError(t, json.Unmarshal(params[0], &txBytes))
+				var tx sui_types.TransactionData
+				_, err := bcs.Unmarshal(txBytes.Data(), &tx)
+				require.NoError(t, err)
+				status := `{"status":"success"}`
+				if tx.V1.GasData.Budget == 1 {
+					status = `{"status":"failure","error":"InsufficientGas"}`
+				}
+				return `{"effects":{"messageVersion":"v1","status":` + status + `,` +
+					`"gasUsed":{"computationCost":"1000","storageCost":"3000","storageRebate":"2500",` +
+					`"nonRefundableStorageFee":"25"}},"events":[],"objectChanges":[],"balanceChanges":[],` +
+					`"input":{"messageVersion":"v1"}}`
+			},
+		},
+	)
+
+	digest, err := sui_types.NewDigest("HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn")
+	require.NoError(t, err)
+	newTx := func(budget uint64) sui_types.TransactionData {
+		gas := []*sui_types.ObjectRef{{ObjectId: sui_types.ObjectID{2}, Version: 3, Digest: *digest}}
+		return sui_types.NewProgrammable(
+			sui_types.SuiAddress{1}, gas, sui_types.NewProgrammableTransactionBuilder().Finish(), budget, 1000,
+		)
+	}
+	txs := []sui_types.TransactionData{newTx(10000), newTx(10000), newTx(10000), newTx(10000), newTx(10000)}
+
+	estimate, err := cli.EstimateBatchGas(context.Background(), txs, 2)
+	require.NoError(t, err)
+	require.Len(t, estimate.Estimates, 5)
+	require.Equal(t, int64(1500), estimate.Estimates[0].Fee)
+	require.Equal(t, uint64(4000), estimate.Estimates[4].Budget)
+	require.Equal(t, int64(7500), estimate.TotalFee)
+	require.Equal(t, uint64(20000), estimate.TotalBudget)
+	require.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2))
+
+	txs[1] = newTx(1)
+	estimate, err = cli.EstimateBatchGas(context.Background(), txs, 0)
+	require.Error(t, err)
+	require.Error(t, estimate.Estimates[1].Err)
+	require.NoError(t, estimate.Estimates[2].Err)
+	require.Equal(t, int64(6000), estimate.TotalFee)
+	require.Equal(t, uint64(16000), estimate.TotalBudget)
+}
+
+func TestClient_EstimateCommandGas(t *testing.T) {
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_devInspectTransactionBlock": func(params []json.RawMessage) any {
+				var kindBytes lib.Base64Data
+				require.NoError(t, json.Unmarshal(params[1], &kindBytes))
+				var kind sui_types.TransactionKind
+				_, err := lib.UnmarshalBcs(kindBytes.Data(), &kind)
+				require.NoError(t, err)
+				commands := len(kind.ProgrammableTransaction.Commands)
+				// the prefix with 2 commands fails, so the 2nd command is grouped with the 3rd one
+				status := `{"status":"success"}`
+				if commands == 2 {
+					status = `{"status":"failure","error":"UnusedValueWithoutDrop"}`
+				}
+				return fmt.Sprintf(
+					`{"effects":{"messageVersion":"v1","status":%s,"gasUsed":{"computationCost":"%d",`+
+						`"storageCost":"%d","storageRebate":"0","nonRefundableStorageFee":"0"}},"events":[]}`,
+					status, commands*1000, 500+commands*100,
+				)
+			},
+		},
+	)
+
+	ptb := sui_types.NewProgrammableTransactionBuilder()
+	for i := 0; i < 3; i++ {
+		amount, err := ptb.Pure(uint64(i + 1))
+		require.NoError(t, err)
+		ptb.Command(
+			sui_types.Command{
+				SplitCoins: &struct {
+					Argument  sui_types.Argument
+					Arguments []sui_types.Argument
+				}{Argument: sui_types.Argument{GasCoin: &lib.EmptyEnum{}}, Arguments: []sui_types.Argument{amount}},
+			},
+		)
+	}
+
+	costs, err := cli.EstimateCommandGas(context.Background(), sui_types.SuiAddress{1}, ptb.Finish(), nil)
+	require.NoError(t, err)
+	require.Equal(
+		t, []CommandGasCost{
+			{FromCommand: 0, ToCommand: 0, ComputationCost: 1000, StorageCost: 600},
+			{FromCommand: 1, ToCommand: 2, ComputationCost: 2000, StorageCost: 200},
+		}, costs,
+	)
+
+	_, err = cli.EstimateCommandGas(context.Background(), sui_types.SuiAddress{1}, sui_types.ProgrammableTransaction{}, nil)
+	require.Error(t, err)
+}
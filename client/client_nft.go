@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 
+	"github.com/coming-chat/go-sui/v2/move_types"
 	"github.com/coming-chat/go-sui/v2/sui_types"
 	"github.com/coming-chat/go-sui/v2/types"
 )
@@ -26,7 +27,7 @@ func (c *Client) MintNFT(
 		*packageId,
 		"devnet_nft",
 		"mint",
-		[]string{},
+		[]move_types.TypeTag{},
 		args,
 		gas,
 		types.NewSafeSuiBigInt(gasBudget),
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// ObjectHistoryEntry a transaction which created or mutated the object
+type ObjectHistoryEntry struct {
+	Transaction sui_types.TransactionDigest
+	// Version the version of the object written by the transaction
+	Version sui_types.SequenceNumber
+	// Created the object is created by the transaction, e.g. at genesis, which is the end of the history
+	Created bool
+}
+
+// TraceObjectHistory walks the `previousTransaction` of the object back at most depth transactions from the latest
+// version, and returns the transactions from the latest one. The walk stops at the transaction creating the object,
+// or when the previous transaction of a version is absent, e.g. the version is pruned by the node.
+// Every step costs a getTransactionBlock and a tryGetPastObject, the version before a transaction is read from
+// `modifiedAtVersions` of its effects, so the walk also stops at the effects produced by a node which omits it.
+// ErrObjectDeleted is returned if the object is deleted.
+func (c *Client) TraceObjectHistory(ctx context.Context, objID suiObjectID, depth int) ([]ObjectHistoryEntry, error) {
+	obj, err := c.GetObject(ctx, objID, &types.SuiObjectDataOptions{ShowPreviousTransaction: true})
+	if err != nil {
+		return nil, err
+	}
+	if obj.Data == nil {
+		if obj.Error != nil && obj.Error.Data.Deleted != nil {
+			return nil, fmt.Errorf("%w: %v", ErrObjectDeleted, objID)
+		}
+		return nil, fmt.Errorf("object %v not found", objID)
+	}
+
+	var history []ObjectHistoryEntry
+	version, previous := obj.Data.Version.Uint64(), obj.Data.PreviousTransaction
+	for len(history) < depth && previous != nil {
+		tx, err := c.GetTransactionBlock(ctx, *previous, types.SuiTransactionBlockResponseOptions{ShowEffects: true})
+		if err != nil {
+			return history, err
+		}
+		if tx.Effects == nil || tx.Effects.Data.V1 == nil {
+			return history, fmt.Errorf("no effects of transaction %v", previous)
+		}
+		entry := ObjectHistoryEntry{
+			Transaction: *previous,
+			Version:     version,
+			Created:     isCreatedBy(objID, tx.Effects.Data),
+		}
+		history = append(history, entry)
+		if entry.Created {
+			break
+		}
+		before, ok := tx.Effects.Data.ModifiedAtVersion(objID)
+		if !ok {
+			break
+		}
+		past, err := c.TryGetPastObject(
+			ctx, objID, before, &types.SuiObjectDataOptions{ShowPreviousTransaction: true},
+		)
+		if err != nil {
+			return history, err
+		}
+		if past.Data.VersionFound == nil {
+			break
+		}
+		version, previous = before, past.Data.VersionFound.PreviousTransaction
+	}
+	return history, nil
+}
+
+func isCreatedBy(objID suiObjectID, effects types.SuiTransactionBlockEffects) bool {
+	for _, created := range effects.V1.Created {
+		id, err := sui_types.NewObjectIdFromHex(created.Reference.ObjectId)
+		if err == nil && *id == objID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_TraceObjectHistory(t *testing.T) {
+	obj := SuiAddressNoErr("0x13")
+	created, mutated := sui_types.ComputeTransactionDigest([]byte{1}), sui_types.ComputeTransactionDigest([]byte{2})
+	objectJson := func(version int, previous sui_types.TransactionDigest) string {
+		return `{"objectId":"` + obj.String() + `","version":"` + strconv.Itoa(version) + `",` +
+			`"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn","previousTransaction":"` + previous.String() + `"}`
+	}
+	effectsJson := func(digest sui_types.TransactionDigest, extra string) string {
+		return `{"digest":"` + digest.String() + `","effects":{"messageVersion":"v1","status":{"status":"success"},` +
+			`"gasUsed":{"computationCost":"0","storageCost":"0","storageRebate":"0","nonRefundableStorageFee":"0"},` +
+			`"transactionDigest":"` + digest.String() + `",` + extra + `}}`
+	}
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_getObject": `{"data":` + objectJson(7, mutated) + `}`,
+			"sui_tryGetPastObject": func(params []json.RawMessage) any {
+				require.Equal(t, "5", string(params[1]))
+				return `{"status":"VersionFound","details":` + objectJson(5, created) + `}`
+			},
+			"sui_getTransactionBlock": func(params []json.RawMessage) any {
+				var digest string
+				require.NoError(t, json.Unmarshal(params[0], &digest))
+				if digest == mutated.String() {
+					return effectsJson(
+						mutated, `"modifiedAtVersions":[{"objectId":"`+obj.String()+`","sequenceNumber":"5"}]`,
+					)
+				}
+				return effectsJson(
+					created, `"created":[{"owner":{"AddressOwner":"0x1"},"reference":{"objectId":"`+
+						obj.String()+`","version":5,"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}}]`,
+				)
+			},
+		},
+	)
+
+	history, err := cli.TraceObjectHistory(context.Background(), *obj, 10)
+	require.NoError(t, err)
+	require.Equal(
+		t, []ObjectHistoryEntry{
+			{Transaction: mutated, Version: 7},
+			{Transaction: created, Version: 5, Created: true},
+		}, history,
+	)
+
+	history, err = cli.TraceObjectHistory(context.Background(), *obj, 1)
+	require.NoError(t, err)
+	require.Equal(t, []ObjectHistoryEntry{{Transaction: mutated, Version: 7}}, history)
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// objectsByTypePageSize is smaller than the max limit since every transaction carries its object changes
+const objectsByTypePageSize = 50
+
+// GetObjectsByType approximates "all live objects of a type" since the node has no such query.
+//
+// A struct can only be packed by its defining module, so the objects are searched in the transactions calling
+// `structTag.Address::structTag.Module`, which are queried by the MoveFunction filter with the object changes:
+//  1. the created objects of the type are collected, every instantiation matches if structTag has no type params
+//  2. the objects are fetched again with options, deleted and wrapped objects are dropped, transferred objects are
+//     returned with the current owner
+//
+// The bounds of the approach:
+//   - objects created through another package calling the module are missed, the node indexes only the
+//     move calls of the transaction itself
+//   - the result is limited to the transaction history kept by the node, pruned full nodes miss old objects
+//   - at most maxPages pages of transactions are scanned, default is `DefaultMaxPages`, ErrTooManyPages is returned
+//     with the objects found so far if there are more
+func (c *Client) GetObjectsByType(
+	ctx context.Context,
+	structTag move_types.StructTag,
+	options *types.SuiObjectDataOptions,
+	maxPages int,
+) ([]types.SuiObjectResponse, error) {
+	query := types.SuiTransactionBlockResponseQuery{
+		Filter: &types.TransactionFilter{
+			MoveFunction: &struct {
+				Package  sui_types.ObjectID `json:"package"`
+				Module   string             `json:"module,omitempty"`
+				Function string             `json:"function,omitempty"`
+			}{Package: structTag.Address, Module: string(structTag.Module)},
+		},
+		Options: &types.SuiTransactionBlockResponseOptions{ShowObjectChanges: true},
+	}
+	limit := uint(objectsByTypePageSize)
+	txs, pageErr := CollectAllPages(
+		ctx, func(ctx context.Context, cursor *suiDigest) (*types.TransactionBlocksPage, error) {
+			return c.QueryTransactionBlocks(ctx, query, cursor, &limit, false)
+		}, maxPages,
+	)
+	if pageErr != nil && pageErr != ErrTooManyPages {
+		return nil, pageErr
+	}
+
+	var (
+		objIds []suiObjectID
+		seen   = make(map[suiObjectID]bool)
+	)
+	for _, tx := range txs {
+		for _, change := range tx.ObjectChanges {
+			created := change.Data.Created
+			if created == nil || seen[created.ObjectId] || !matchStructTag(structTag, created.ObjectType) {
+				continue
+			}
+			seen[created.ObjectId] = true
+			objIds = append(objIds, created.ObjectId)
+		}
+	}
+	objs, err := c.multiGetObjectsChunked(ctx, objIds, options)
+	if err != nil {
+		return nil, err
+	}
+	live := make([]types.SuiObjectResponse, 0, len(objs))
+	for _, obj := range objs {
+		if obj.Data != nil {
+			live = append(live, obj)
+		}
+	}
+	return live, pageErr
+}
+
+// matchStructTag compares the object type with structTag, the type params are ignored if structTag has none
+func matchStructTag(structTag move_types.StructTag, objectType string) bool {
+	tag, err := move_types.ParseStructTag(objectType)
+	if err != nil {
+		return false
+	}
+	if len(structTag.TypeParams) == 0 {
+		tag.TypeParams = nil
+	}
+	return tag.String() == structTag.String()
+}
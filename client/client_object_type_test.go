@@ -0,0 +1,22 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchStructTag(t *testing.T) {
+	coin, err := move_types.ParseStructTag("0x2::coin::Coin")
+	require.NoError(t, err)
+	suiCoin, err := move_types.ParseStructTag("0x2::coin::Coin<0x2::sui::SUI>")
+	require.NoError(t, err)
+
+	require.True(t, matchStructTag(*coin, "0x2::coin::Coin<0x2::sui::SUI>"))
+	require.True(t, matchStructTag(*coin, "0x2::coin::Coin<0xabc::usdc::USDC>"))
+	require.True(t, matchStructTag(*suiCoin, "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x2::sui::SUI>"))
+	require.False(t, matchStructTag(*suiCoin, "0x2::coin::Coin<0xabc::usdc::USDC>"))
+	require.False(t, matchStructTag(*coin, "0x2::coin::TreasuryCap<0x2::sui::SUI>"))
+	require.False(t, matchStructTag(*coin, "invalid"))
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// protocolConfigTTL the protocol version only changes at the epoch boundary, so the current config is cached for a while
+const protocolConfigTTL = 10 * time.Minute
+
+// protocolConfigCache the configs of the versions never change, the current one expires after protocolConfigTTL
+type protocolConfigCache struct {
+	mu        sync.Mutex
+	current   *types.ProtocolConfig
+	fetchedAt time.Time
+	versions  map[uint64]*types.ProtocolConfig
+}
+
+func (c *protocolConfigCache) get(version *uint64) *types.ProtocolConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if version != nil {
+		return c.versions[*version]
+	}
+	if c.current == nil || time.Since(c.fetchedAt) > protocolConfigTTL {
+		return nil
+	}
+	return c.current
+}
+
+func (c *protocolConfigCache) put(version *uint64, config *types.ProtocolConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.versions == nil {
+		c.versions = make(map[uint64]*types.ProtocolConfig)
+	}
+	c.versions[config.ProtocolVersion.Uint64()] = config
+	if version == nil {
+		c.current, c.fetchedAt = config, time.Now()
+	}
+}
+
+// GetProtocolConfig returns the protocol config of the version, the current version of the network if version is nil.
+// The node only serves the versions between MinSupportedProtocolVersion and MaxSupportedProtocolVersion.
+func (c *Client) GetProtocolConfig(ctx context.Context, version *types.SafeSuiBigInt[uint64]) (
+	*types.ProtocolConfig,
+	error,
+) {
+	var resp types.ProtocolConfig
+	return &resp, c.CallContext(ctx, &resp, getProtocolConfig, version)
+}
+
+// CachedProtocolConfig returns the protocol config of the version as GetProtocolConfig, which is cached by the client.
+// The config of the current version is refetched after 10 minutes, since the version changes at the epoch boundary
+func (c *Client) CachedProtocolConfig(ctx context.Context, version *uint64) (*types.ProtocolConfig, error) {
+	if config := c.protocolConfigs.get(version); config != nil {
+		return config, nil
+	}
+	var v *types.SafeSuiBigInt[uint64]
+	if version != nil {
+		safe := types.NewSafeSuiBigInt(*version)
+		v = &safe
+	}
+	config, err := c.GetProtocolConfig(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	c.protocolConfigs.put(version, config)
+	return config, nil
+}
+
+// CheckProtocolSupport checks the feature flags of sui_types.TransactionData.RequiredProtocolFeatures against the cached
+// protocol config of the network. An *UnsupportedFeatureError is returned for the first feature not enabled,
+// which names the first version enabling it, searched in the versions supported by the node
+func (c *Client) CheckProtocolSupport(ctx context.Context, tx sui_types.TransactionData) error {
+	features := tx.RequiredProtocolFeatures()
+	if len(features) == 0 {
+		return nil
+	}
+	config, err := c.CachedProtocolConfig(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, feature := range features {
+		if config.FeatureFlags[feature.FeatureFlag] {
+			continue
+		}
+		required, err := c.findFeatureVersion(
+			ctx, feature.FeatureFlag, config.ProtocolVersion.Uint64()+1, config.MaxSupportedProtocolVersion.Uint64(),
+		)
+		if err != nil {
+			return err
+		}
+		return &UnsupportedFeatureError{
+			Feature:         feature,
+			ProtocolVersion: config.ProtocolVersion.Uint64(),
+			RequiredVersion: required,
+		}
+	}
+	return nil
+}
+
+// findFeatureVersion binary searches the first version in [from, to] enabling the feature flag, 0 if there is none.
+// The feature flags are never disabled once enabled by a version
+func (c *Client) findFeatureVersion(ctx context.Context, featureFlag string, from, to uint64) (uint64, error) {
+	var found uint64
+	for from <= to {
+		mid := from + (to-from)/2
+		config, err := c.CachedProtocolConfig(ctx, &mid)
+		if err != nil {
+			return 0, err
+		}
+		if config.FeatureFlags[featureFlag] {
+			found, to = mid, mid-1
+		} else {
+			from = mid + 1
+		}
+	}
+	return found, nil
+}
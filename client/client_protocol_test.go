@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CheckProtocolSupport(t *testing.T) {
+	const current, upgradeVersion = 10, 14
+	var calls int32
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_getProtocolConfig": func(params []json.RawMessage) any {
+				atomic.AddInt32(&calls, 1)
+				version := current
+				if len(params) > 0 && string(params[0]) != "null" {
+					var v string
+					require.NoError(t, json.Unmarshal(params[0], &v))
+					n, err := strconv.Atoi(v)
+					require.NoError(t, err)
+					version = n
+				}
+				flags := `{"package_upgrades":` + strconv.FormatBool(version >= upgradeVersion) + `,"random_beacon":false}`
+				return `{"minSupportedProtocolVersion":"1","maxSupportedProtocolVersion":"20",` +
+					`"protocolVersion":"` + strconv.Itoa(version) + `","featureFlags":` + flags + `,` +
+					`"attributes":{"max_arguments":{"u32":"512"},"max_age":null}}`
+			},
+		},
+	)
+	ctx := context.Background()
+
+	config, err := cli.GetProtocolConfig(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(current), config.ProtocolVersion.Uint64())
+	require.Equal(t, "512", config.Attributes["max_arguments"]["u32"])
+	require.Nil(t, config.Attributes["max_age"])
+
+	newTx := func(command sui_types.Command, inputs ...sui_types.CallArg) sui_types.TransactionData {
+		return sui_types.NewProgrammable(
+			sui_types.SuiAddress{1}, nil,
+			sui_types.ProgrammableTransaction{Inputs: inputs, Commands: []sui_types.Command{command}}, 1000, 1000,
+		)
+	}
+	atomic.StoreInt32(&calls, 0)
+	transfer := sui_types.Command{
+		TransferObjects: &struct {
+			Arguments []sui_types.Argument
+			Argument  sui_types.Argument
+		}{Argument: sui_types.Argument{GasCoin: &lib.EmptyEnum{}}},
+	}
+	require.NoError(t, cli.CheckProtocolSupport(ctx, newTx(transfer)))
+	require.Equal(t, int32(0), atomic.LoadInt32(&calls))
+
+	upgrade := sui_types.Command{
+		Upgrade: &struct {
+			Bytes    [][]uint8
+			Objects  []sui_types.ObjectID
+			ObjectID sui_types.ObjectID
+			Argument sui_types.Argument
+		}{Argument: sui_types.Argument{GasCoin: &lib.EmptyEnum{}}},
+	}
+	err = cli.CheckProtocolSupport(ctx, newTx(upgrade))
+	require.ErrorIs(t, err, ErrFeatureNotSupported)
+	var featureErr *UnsupportedFeatureError
+	require.ErrorAs(t, err, &featureErr)
+	require.Equal(t, sui_types.ProtocolFeaturePackageUpgrade, featureErr.Feature)
+	require.Equal(t, uint64(current), featureErr.ProtocolVersion)
+	require.Equal(t, uint64(upgradeVersion), featureErr.RequiredVersion)
+	require.Contains(t, err.Error(), "requires protocol version 14")
+
+	// the configs are cached
+	fetched := atomic.LoadInt32(&calls)
+	err = cli.CheckProtocolSupport(ctx, newTx(upgrade))
+	require.ErrorIs(t, err, ErrFeatureNotSupported)
+	require.Equal(t, fetched, atomic.LoadInt32(&calls))
+
+	random := sui_types.CallArg{
+		Object: &sui_types.ObjectArg{
+			SharedObject: &struct {
+				Id                   sui_types.ObjectID
+				InitialSharedVersion sui_types.SequenceNumber
+				Mutable              bool
+			}{Id: *sui_types.SuiRandomObjectId, InitialSharedVersion: 1},
+		},
+	}
+	err = cli.CheckProtocolSupport(ctx, newTx(transfer, random))
+	require.ErrorAs(t, err, &featureErr)
+	require.Equal(t, sui_types.ProtocolFeatureRandom, featureErr.Feature)
+	require.Equal(t, uint64(0), featureErr.RequiredVersion)
+}
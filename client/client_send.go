@@ -0,0 +1,536 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// TransactionSigner signs the BCS bytes of a transaction data with the intent, e.g. account.Account
+type TransactionSigner interface {
+	SignSecureWithoutEncode(txnBytes []byte, intent sui_types.Intent) (sui_types.Signature, error)
+}
+
+type SendOption func(*sendOptions)
+
+type sendOptions struct {
+	requestType       types.ExecuteTransactionRequestType
+	gasBumpRetries    int
+	gasBumpFactor     float64
+	objRefreshRetries int
+	validateGasCoins  bool
+	resolveShared     bool
+	checkProtocol     bool
+}
+
+// WithRequestType default is `TxnRequestTypeWaitForLocalExecution`
+func WithRequestType(requestType types.ExecuteTransactionRequestType) SendOption {
+	return func(o *sendOptions) {
+		o.requestType = requestType
+	}
+}
+
+// WithGasAutoBump resends the transaction with the gas budget multiplied by factor, at most maxRetries times,
+// if the execution failed with InsufficientGas. Other failures are returned as is.
+func WithGasAutoBump(maxRetries int, factor float64) SendOption {
+	return func(o *sendOptions) {
+		o.gasBumpRetries = maxRetries
+		o.gasBumpFactor = factor
+	}
+}
+
+// WithObjectRefresh refreshes the owned inputs and gas payment to their latest versions, then re-signs and resends
+// the transaction, at most maxRetries times, if it was rejected with ErrObjectVersionMismatch.
+func WithObjectRefresh(maxRetries int) SendOption {
+	return func(o *sendOptions) {
+		o.objRefreshRetries = maxRetries
+	}
+}
+
+// WithGasCoinValidation checks that the gas payment are SUI coins by ValidateGasCoins before signing,
+// it costs an extra multiGetObjects so it is disabled by default
+func WithGasCoinValidation() SendOption {
+	return func(o *sendOptions) {
+		o.validateGasCoins = true
+	}
+}
+
+// WithSharedInputResolution converts the shared objects which are used as owned inputs to shared inputs
+// by ResolveSharedInputs before signing, it costs an extra multiGetObjects so it is disabled by default
+func WithSharedInputResolution() SendOption {
+	return func(o *sendOptions) {
+		o.resolveShared = true
+	}
+}
+
+// WithProtocolCheck rejects the transaction using a feature which is not enabled at the protocol version of
+// the network by CheckProtocolSupport before signing, the protocol config is cached by the client
+func WithProtocolCheck() SendOption {
+	return func(o *sendOptions) {
+		o.checkProtocol = true
+	}
+}
+
+// SignAndExecuteTransaction signs the transaction with the default intent and executes it.
+// The effects are always requested since the retry options depend on them.
+// NOTE: the retries update the gas data and inputs of tx in place
+func (c *Client) SignAndExecuteTransaction(
+	ctx context.Context,
+	tx sui_types.TransactionData,
+	signer TransactionSigner,
+	options *types.SuiTransactionBlockResponseOptions,
+	opts ...SendOption,
+) (*types.SuiTransactionBlockResponse, error) {
+	sendOpts := sendOptions{
+		requestType: types.TxnRequestTypeWaitForLocalExecution,
+	}
+	for _, opt := range opts {
+		opt(&sendOpts)
+	}
+	if sendOpts.gasBumpRetries > 0 && sendOpts.gasBumpFactor <= 1 {
+		return nil, errors.New("gas bump factor should be greater than 1")
+	}
+	if tx.V1 == nil {
+		return nil, errors.New("nil transaction data")
+	}
+	respOptions := types.SuiTransactionBlockResponseOptions{}
+	if options != nil {
+		respOptions = *options
+	}
+	respOptions.ShowEffects = true
+	if sendOpts.validateGasCoins {
+		err := c.ValidateGasCoins(ctx, tx.V1.GasData.Payment)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sendOpts.resolveShared {
+		err := c.ResolveSharedInputs(ctx, &tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sendOpts.checkProtocol {
+		err := c.CheckProtocolSupport(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gasRetries, objRetries := 0, 0
+	for {
+		resp, err := c.signAndExecute(ctx, tx, signer, &respOptions, sendOpts.requestType)
+		if err != nil {
+			if !errors.Is(err, ErrObjectVersionMismatch) || objRetries >= sendOpts.objRefreshRetries {
+				return resp, err
+			}
+			objRetries++
+			err = c.refreshObjectRefs(ctx, &tx)
+			if err != nil {
+				return resp, err
+			}
+			continue
+		}
+		if gasRetries >= sendOpts.gasBumpRetries || resp.Effects == nil || !resp.Effects.Data.IsInsufficientGas() {
+			return resp, nil
+		}
+		gasRetries++
+		// the failed transaction still consumed the gas and bumped the versions of owned inputs
+		err = refreshObjectRefsFromEffects(&tx, resp.Effects.Data)
+		if err != nil {
+			return resp, err
+		}
+		tx.V1.GasData.Budget = uint64(float64(tx.V1.GasData.Budget) * sendOpts.gasBumpFactor)
+	}
+}
+
+func (c *Client) signAndExecute(
+	ctx context.Context,
+	tx sui_types.TransactionData,
+	signer TransactionSigner,
+	options *types.SuiTransactionBlockResponseOptions,
+	requestType types.ExecuteTransactionRequestType,
+) (*types.SuiTransactionBlockResponse, error) {
+	txBytes, err := tx.TransactionBytes()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.SignSecureWithoutEncode(txBytes, sui_types.DefaultIntent())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.ExecuteTransactionBlock(ctx, txBytes, []any{signature}, options, requestType)
+	return resp, wrapExecuteError(err)
+}
+
+// wrapExecuteError wraps the rejection of executeTransactionBlock with the error of the reason
+func wrapExecuteError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case isObjectVersionMismatch(err):
+		return fmt.Errorf("%w: %v", ErrObjectVersionMismatch, err)
+	case isSharedObjectAsOwned(err):
+		return fmt.Errorf("%w: %v", ErrSharedObjectAsOwned, err)
+	case isObjectLockConflict(err):
+		return fmt.Errorf("%w: %v", ErrObjectLockConflict, err)
+	default:
+		return err
+	}
+}
+
+// SignedTransaction the BCS bytes of TransactionData and its signatures, see ExecuteTransactionBlock
+type SignedTransaction struct {
+	TxBytes    suiBase64Data
+	Signatures []any
+}
+
+// ExecuteResult the result of a transaction executed by ExecuteMany
+type ExecuteResult struct {
+	Response *types.SuiTransactionBlockResponse
+	// Err the transaction was not submitted or rejected by the node, a failed execution is in the effects of Response
+	Err error
+}
+
+// ExecuteMany executes the independent transactions with at most `parallelism` concurrent requests, and returns
+// the results in the order of txs. The first error is returned with the results as an error of its index,
+// so all the failures can still be checked in the results.
+// Executing the transactions which use the same owned object concurrently may equivocate the object, i.e. lock it
+// until the end of the epoch, so a transaction using a gas coin of an earlier transaction in txs is not submitted
+// and fails with ErrObjectLockConflict, which is also the error of the lock conflicts rejected by the node.
+// NOTE: the other owned inputs are not checked locally, since the immutable objects can't be told apart from
+// the owned ones in the transaction data
+func (c *Client) ExecuteMany(
+	ctx context.Context,
+	txs []SignedTransaction,
+	parallelism int,
+	options *types.SuiTransactionBlockResponseOptions,
+	requestType types.ExecuteTransactionRequestType,
+) ([]ExecuteResult, error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	results := make([]ExecuteResult, len(txs))
+	gasUsers := make(map[sui_types.ObjectID]int)
+	var submit []int
+	for i, tx := range txs {
+		data, err := sui_types.DecodeTransactionData(tx.TxBytes)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Err = checkGasConflict(gasUsers, i, data.V1.GasData.Payment)
+		if results[i].Err == nil {
+			submit = append(submit, i)
+		}
+	}
+
+	var wg sync.WaitGroup
+	indexes := make(chan int)
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				resp, err := c.ExecuteTransactionBlock(ctx, txs[i].TxBytes, txs[i].Signatures, options, requestType)
+				results[i] = ExecuteResult{Response: resp, Err: wrapExecuteError(err)}
+			}
+		}()
+	}
+	for _, i := range submit {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, result := range results {
+		if result.Err != nil {
+			return results, fmt.Errorf("transaction %d: %w", i, result.Err)
+		}
+	}
+	return results, nil
+}
+
+// checkGasConflict records the gas coins of the transaction at index, and fails if a coin is used by an earlier one
+func checkGasConflict(gasUsers map[sui_types.ObjectID]int, index int, payment []*sui_types.ObjectRef) error {
+	for _, ref := range payment {
+		if user, ok := gasUsers[ref.ObjectId]; ok {
+			return fmt.Errorf("%w: gas coin %v is used by transaction %d", ErrObjectLockConflict, ref.ObjectId, user)
+		}
+	}
+	for _, ref := range payment {
+		gasUsers[ref.ObjectId] = index
+	}
+	return nil
+}
+
+// ResolveSharedInputs checks the owners of the owned object inputs of the programmable transaction, and converts
+// the shared ones to shared inputs in place, which avoids ErrSharedObjectAsOwned.
+// A converted input is immutable if the commands only pass it to the Move functions by immutable reference,
+// which is looked up by GetNormalizedMoveFunction, the Clock and Random objects are always immutable.
+// A shared gas payment can not be converted and is an error.
+func (c *Client) ResolveSharedInputs(ctx context.Context, tx *sui_types.TransactionData) error {
+	if tx.V1 == nil {
+		return errors.New("nil transaction data")
+	}
+	var objIds []suiObjectID
+	for _, ref := range tx.V1.GasData.Payment {
+		objIds = append(objIds, ref.ObjectId)
+	}
+	pt := tx.V1.Kind.ProgrammableTransaction
+	if pt != nil {
+		for _, input := range pt.Inputs {
+			if input.Object != nil && input.Object.ImmOrOwnedObject != nil {
+				objIds = append(objIds, input.Object.ImmOrOwnedObject.ObjectId)
+			}
+		}
+	}
+	if len(objIds) == 0 {
+		return nil
+	}
+	objs, err := c.multiGetObjectsChunked(ctx, objIds, &types.SuiObjectDataOptions{ShowOwner: true})
+	if err != nil {
+		return err
+	}
+	sharedVersions := make(map[sui_types.ObjectID]uint64)
+	for _, obj := range objs {
+		if obj.Data == nil || obj.Data.Owner == nil || obj.Data.Owner.ObjectOwnerInternal == nil {
+			continue
+		}
+		shared := obj.Data.Owner.ObjectOwnerInternal.Shared
+		if shared != nil && shared.InitialSharedVersion != nil {
+			sharedVersions[obj.Data.ObjectId] = *shared.InitialSharedVersion
+		}
+	}
+	for _, ref := range tx.V1.GasData.Payment {
+		if _, ok := sharedVersions[ref.ObjectId]; ok {
+			return fmt.Errorf("%w: gas payment %v is shared", ErrSharedObjectAsOwned, ref.ObjectId)
+		}
+	}
+	if pt == nil {
+		return nil
+	}
+	converted := make(map[uint16]bool)
+	for i, input := range pt.Inputs {
+		if input.Object == nil || input.Object.ImmOrOwnedObject == nil {
+			continue
+		}
+		if _, ok := sharedVersions[input.Object.ImmOrOwnedObject.ObjectId]; ok {
+			converted[uint16(i)] = false
+		}
+	}
+	if len(converted) == 0 {
+		return nil
+	}
+	if err := c.markMutableInputs(ctx, pt, converted); err != nil {
+		return err
+	}
+	for i, input := range pt.Inputs {
+		if _, ok := converted[uint16(i)]; !ok {
+			continue
+		}
+		id := input.Object.ImmOrOwnedObject.ObjectId
+		version := sharedVersions[id]
+		mutable := converted[uint16(i)] && id != *sui_types.SuiClockObjectId && id != *sui_types.SuiRandomObjectId
+		pt.Inputs[i] = sui_types.CallArg{
+			Object: &sui_types.ObjectArg{
+				SharedObject: &struct {
+					Id                   sui_types.ObjectID
+					InitialSharedVersion sui_types.SequenceNumber
+					Mutable              bool
+				}{Id: id, InitialSharedVersion: version, Mutable: mutable},
+			},
+		}
+	}
+	return nil
+}
+
+// markMutableInputs sets inputs[i] to true if the input i is used mutably by the commands of pt,
+// which is any use except the MoveCall parameters of immutable references
+func (c *Client) markMutableInputs(
+	ctx context.Context,
+	pt *sui_types.ProgrammableTransaction,
+	inputs map[uint16]bool,
+) error {
+	mark := func(args ...sui_types.Argument) {
+		for _, arg := range args {
+			if arg.Input != nil {
+				if _, ok := inputs[*arg.Input]; ok {
+					inputs[*arg.Input] = true
+				}
+			}
+		}
+	}
+	functions := make(map[string]*types.SuiMoveNormalizedFunction)
+	for _, command := range pt.Commands {
+		switch {
+		case command.MoveCall != nil:
+			call := command.MoveCall
+			used := false
+			for _, arg := range call.Arguments {
+				if arg.Input != nil {
+					_, ok := inputs[*arg.Input]
+					used = used || ok
+				}
+			}
+			if !used {
+				continue
+			}
+			key := call.Package.String() + "::" + string(call.Module) + "::" + string(call.Function)
+			fn, ok := functions[key]
+			if !ok {
+				var err error
+				fn, err = c.GetNormalizedMoveFunction(ctx, call.Package, string(call.Module), string(call.Function))
+				if err != nil {
+					return err
+				}
+				functions[key] = fn
+			}
+			for i, arg := range call.Arguments {
+				if i < len(fn.Parameters) && isImmutableReference(fn.Parameters[i]) {
+					continue
+				}
+				mark(arg)
+			}
+		case command.TransferObjects != nil:
+			mark(command.TransferObjects.Arguments...)
+			mark(command.TransferObjects.Argument)
+		case command.SplitCoins != nil:
+			mark(command.SplitCoins.Argument)
+			mark(command.SplitCoins.Arguments...)
+		case command.MergeCoins != nil:
+			mark(command.MergeCoins.Argument)
+			mark(command.MergeCoins.Arguments...)
+		case command.MakeMoveVec != nil:
+			mark(command.MakeMoveVec.Arguments...)
+		case command.Upgrade != nil:
+			mark(command.Upgrade.Argument)
+		}
+	}
+	return nil
+}
+
+// isImmutableReference the normalized Move type is `{"Reference": ...}`
+func isImmutableReference(param interface{}) bool {
+	m, ok := param.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = m["Reference"]
+	return ok
+}
+
+// ValidateGasCoins checks that every gas payment object is a `0x2::coin::Coin<0x2::sui::SUI>`,
+// the node rejects other coins as gas with a less obvious error
+func (c *Client) ValidateGasCoins(ctx context.Context, gasPayment []*sui_types.ObjectRef) error {
+	objIds := make([]suiObjectID, 0, len(gasPayment))
+	for i, ref := range gasPayment {
+		if ref == nil {
+			return fmt.Errorf("gas payment %d is nil", i)
+		}
+		objIds = append(objIds, ref.ObjectId)
+	}
+	objs, err := c.multiGetObjectsChunked(ctx, objIds, &types.SuiObjectDataOptions{ShowType: true})
+	if err != nil {
+		return err
+	}
+	for i, obj := range objs {
+		if obj.Data == nil || obj.Data.Type == nil {
+			return fmt.Errorf("gas coin %v not found", objIds[i])
+		}
+		if !isSuiCoinType(*obj.Data.Type) {
+			return fmt.Errorf("%w: %v is %v", ErrGasCoinNotSui, objIds[i], *obj.Data.Type)
+		}
+	}
+	return nil
+}
+
+func isSuiCoinType(objectType string) bool {
+	tag, err := move_types.ParseStructTag(objectType)
+	if err != nil {
+		return false
+	}
+	return tag.String() == "0x2::coin::Coin<"+types.SuiCoinType+">"
+}
+
+// refreshObjectRefs updates the gas payment and owned inputs of tx to their latest versions on chain
+func (c *Client) refreshObjectRefs(ctx context.Context, tx *sui_types.TransactionData) error {
+	var objIds []suiObjectID
+	for _, ref := range tx.V1.GasData.Payment {
+		objIds = append(objIds, ref.ObjectId)
+	}
+	if pt := tx.V1.Kind.ProgrammableTransaction; pt != nil {
+		for _, input := range pt.Inputs {
+			if input.Object != nil && input.Object.ImmOrOwnedObject != nil {
+				objIds = append(objIds, input.Object.ImmOrOwnedObject.ObjectId)
+			}
+		}
+	}
+	objs, err := c.multiGetObjectsChunked(ctx, objIds, &types.SuiObjectDataOptions{})
+	if err != nil {
+		return err
+	}
+	refs := make(map[sui_types.ObjectID]*sui_types.ObjectRef, len(objs))
+	for _, obj := range objs {
+		if obj.Data == nil {
+			continue
+		}
+		ref := obj.Data.Reference()
+		refs[ref.ObjectId] = &ref
+	}
+	for i, payment := range tx.V1.GasData.Payment {
+		if ref, ok := refs[payment.ObjectId]; ok {
+			tx.V1.GasData.Payment[i] = ref
+		}
+	}
+	replaceOwnedInputs(tx, refs)
+	return nil
+}
+
+// refreshObjectRefsFromEffects updates the gas payment and owned inputs of tx to the versions after effects.
+// Multiple gas coins have been merged into the first one, which becomes the only gas payment
+func refreshObjectRefsFromEffects(tx *sui_types.TransactionData, effects types.SuiTransactionBlockEffects) error {
+	if effects.V1 == nil {
+		return errors.New("nil transaction effects")
+	}
+	refs := make(map[sui_types.ObjectID]*sui_types.ObjectRef)
+	for _, obj := range effects.V1.Mutated {
+		ref, err := obj.Reference.ObjectRef()
+		if err != nil {
+			return err
+		}
+		refs[ref.ObjectId] = ref
+	}
+	gasRef, err := effects.V1.GasObject.Reference.ObjectRef()
+	if err != nil {
+		return err
+	}
+	tx.V1.GasData.Payment = []*sui_types.ObjectRef{gasRef}
+	replaceOwnedInputs(tx, refs)
+	return nil
+}
+
+// replaceOwnedInputs replaces the owned object inputs of a programmable transaction with the refs which have the same id
+func replaceOwnedInputs(tx *sui_types.TransactionData, refs map[sui_types.ObjectID]*sui_types.ObjectRef) {
+	pt := tx.V1.Kind.ProgrammableTransaction
+	if pt == nil {
+		return
+	}
+	for i, input := range pt.Inputs {
+		if input.Object == nil || input.Object.ImmOrOwnedObject == nil {
+			continue
+		}
+		if ref, ok := refs[input.Object.ImmOrOwnedObject.ObjectId]; ok {
+			pt.Inputs[i] = sui_types.CallArg{Object: &sui_types.ObjectArg{ImmOrOwnedObject: ref}}
+		}
+	}
+}
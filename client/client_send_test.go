@@ -0,0 +1,297 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshObjectRefsFromEffects(t *testing.T) {
+	digest, err := sui_types.NewDigest("HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn")
+	require.NoError(t, err)
+	gas1, gas2, obj := SuiAddressNoErr("0x11"), SuiAddressNoErr("0x12"), SuiAddressNoErr("0x13")
+
+	ptb := sui_types.NewProgrammableTransactionBuilder()
+	err = ptb.TransferObject(*Address, []*sui_types.ObjectRef{{ObjectId: *obj, Version: 1, Digest: *digest}})
+	require.NoError(t, err)
+	tx := sui_types.NewProgrammable(
+		*Address, []*sui_types.ObjectRef{
+			{ObjectId: *gas1, Version: 1, Digest: *digest},
+			{ObjectId: *gas2, Version: 1, Digest: *digest},
+		}, ptb.Finish(), 1000, 1000,
+	)
+
+	newRef := func(id *suiObjectID) types.OwnedObjectRef {
+		return types.OwnedObjectRef{Reference: types.SuiObjectRef{ObjectId: id.String(), Version: 5, Digest: *digest}}
+	}
+	effects := types.SuiTransactionBlockEffects{
+		V1: &types.SuiTransactionBlockEffectsV1{
+			Status:    types.ExecutionStatus{Status: types.ExecutionStatusFailure, Error: "InsufficientGas"},
+			Mutated:   []types.OwnedObjectRef{newRef(gas1), newRef(obj)},
+			GasObject: newRef(gas1),
+		},
+	}
+	require.True(t, effects.IsInsufficientGas())
+
+	err = refreshObjectRefsFromEffects(&tx, effects)
+	require.NoError(t, err)
+	require.Equal(t, []*sui_types.ObjectRef{{ObjectId: *gas1, Version: 5, Digest: *digest}}, tx.V1.GasData.Payment)
+	inputs := tx.V1.Kind.ProgrammableTransaction.Inputs
+	require.Equal(t, uint64(5), inputs[len(inputs)-1].Object.ImmOrOwnedObject.Version)
+}
+
+func TestIsObjectVersionMismatch(t *testing.T) {
+	err := &jsonError{
+		Code: -32002,
+		Message: "Transaction validator signing failed due to issues with transaction inputs: " +
+			"Object ID 0x11 Version 0x1 Digest HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn is not available for consumption, current version: 0x5",
+	}
+	require.True(t, isObjectVersionMismatch(err))
+	require.False(t, isObjectVersionMismatch(&jsonError{Code: -32002, Message: "InsufficientGas"}))
+	require.False(t, isObjectVersionMismatch(HTTPError{StatusCode: 500}))
+}
+
+func TestIsSuiCoinType(t *testing.T) {
+	require.True(t, isSuiCoinType("0x2::coin::Coin<0x2::sui::SUI>"))
+	require.True(
+		t,
+		isSuiCoinType(
+			"0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<"+
+				"0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI>",
+		),
+	)
+	require.False(t, isSuiCoinType("0x2::coin::Coin<0x5d4b302506645c37ff133b98c4b50a5ae14841659738d6d733d59d0d217a93bf::coin::COIN>"))
+	require.False(t, isSuiCoinType("0x3::staking_pool::StakedSui"))
+}
+
+func TestIsSharedObjectAsOwned(t *testing.T) {
+	err := &jsonError{
+		Code: -32002,
+		Message: "Transaction validator signing failed due to issues with transaction inputs, please review the errors " +
+			"and try again: Object used as owned is not owned.",
+	}
+	require.True(t, isSharedObjectAsOwned(err))
+	require.False(t, isSharedObjectAsOwned(&jsonError{Code: -32002, Message: "InsufficientGas"}))
+	require.False(t, isSharedObjectAsOwned(HTTPError{StatusCode: 500}))
+}
+
+func TestClient_ResolveSharedInputs(t *testing.T) {
+	digest, err := sui_types.NewDigest("HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn")
+	require.NoError(t, err)
+	gas, owned, shared := SuiAddressNoErr("0x11"), SuiAddressNoErr("0x12"), SuiAddressNoErr("0x13")
+	objectJson := func(id *suiObjectID, owner string) string {
+		return `{"data":{"objectId":"` + id.String() + `","version":"3","digest":"` + digest.String() + `",` +
+			`"owner":` + owner + `}}`
+	}
+	var sharedGas bool
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_getNormalizedMoveFunction": func(params []json.RawMessage) any {
+				first := `{"Reference":{"Struct":{"address":"0x2","module":"pool","name":"Pool","typeArguments":[]}}}`
+				if string(params[2]) == `"swap"` {
+					first = `{"MutableReference":{"Struct":{"address":"0x2","module":"pool","name":"Pool","typeArguments":[]}}}`
+				}
+				return `{"visibility":"Public","isEntry":true,"typeParameters":[],"parameters":[` + first + `,` +
+					`{"MutableReference":{"Struct":{"address":"0x2","module":"clock","name":"Clock","typeArguments":[]}}}],` +
+					`"return":[]}`
+			},
+			"sui_multiGetObjects": func(params []json.RawMessage) any {
+				gasOwner := `{"AddressOwner":"0x1"}`
+				if sharedGas {
+					gasOwner = `{"Shared":{"initial_shared_version":2}}`
+				}
+				return `[` + objectJson(gas, gasOwner) + `,` + objectJson(owned, `{"AddressOwner":"0x1"}`) + `,` +
+					objectJson(shared, `{"Shared":{"initial_shared_version":7}}`) + `,` +
+					objectJson(sui_types.SuiClockObjectId, `{"Shared":{"initial_shared_version":1}}`) + `]`
+			},
+		},
+	)
+
+	newTx := func() sui_types.TransactionData {
+		ptb := sui_types.NewProgrammableTransactionBuilder()
+		err = ptb.TransferObject(
+			*Address, []*sui_types.ObjectRef{
+				{ObjectId: *owned, Version: 3, Digest: *digest},
+				{ObjectId: *shared, Version: 3, Digest: *digest},
+			},
+		)
+		require.NoError(t, err)
+		return sui_types.NewProgrammable(
+			*Address, []*sui_types.ObjectRef{{ObjectId: *gas, Version: 3, Digest: *digest}}, ptb.Finish(), 1000, 1000,
+		)
+	}
+	tx := newTx()
+	inputs := append([]sui_types.CallArg{}, tx.V1.Kind.ProgrammableTransaction.Inputs...)
+	err = cli.ResolveSharedInputs(context.Background(), &tx)
+	require.NoError(t, err)
+	resolved := tx.V1.Kind.ProgrammableTransaction.Inputs
+	require.Len(t, resolved, len(inputs))
+	sharedInputs := 0
+	for i, input := range resolved {
+		if input.Object == nil || input.Object.SharedObject == nil {
+			require.Equal(t, inputs[i], input)
+			continue
+		}
+		require.Equal(t, *shared, input.Object.SharedObject.Id)
+		require.Equal(t, uint64(7), input.Object.SharedObject.InitialSharedVersion)
+		require.True(t, input.Object.SharedObject.Mutable)
+		require.Equal(t, *shared, inputs[i].Object.ImmOrOwnedObject.ObjectId)
+		sharedInputs++
+	}
+	require.Equal(t, 1, sharedInputs)
+
+	// the shared object passed by immutable reference and the clock are immutable
+	newMoveCallTx := func(function move_types.Identifier) sui_types.TransactionData {
+		ptb := sui_types.NewProgrammableTransactionBuilder()
+		err = ptb.MoveCall(
+			*sui_types.SuiFrameworkPackageId, "pool", function, nil, []sui_types.CallArg{
+				{Object: &sui_types.ObjectArg{
+					ImmOrOwnedObject: &sui_types.ObjectRef{ObjectId: *shared, Version: 3, Digest: *digest},
+				}},
+				{Object: &sui_types.ObjectArg{
+					ImmOrOwnedObject: &sui_types.ObjectRef{ObjectId: *sui_types.SuiClockObjectId, Version: 3, Digest: *digest},
+				}},
+			},
+		)
+		require.NoError(t, err)
+		return sui_types.NewProgrammable(
+			*Address, []*sui_types.ObjectRef{{ObjectId: *gas, Version: 3, Digest: *digest}}, ptb.Finish(), 1000, 1000,
+		)
+	}
+	for function, mutable := range map[move_types.Identifier]bool{"price": false, "swap": true} {
+		tx = newMoveCallTx(function)
+		require.NoError(t, cli.ResolveSharedInputs(context.Background(), &tx))
+		resolved = tx.V1.Kind.ProgrammableTransaction.Inputs
+		require.Len(t, resolved, 2)
+		require.Equal(t, *shared, resolved[0].Object.SharedObject.Id)
+		require.Equal(t, mutable, resolved[0].Object.SharedObject.Mutable, function)
+		require.Equal(t, *sui_types.SuiClockObjectId, resolved[1].Object.SharedObject.Id)
+		require.False(t, resolved[1].Object.SharedObject.Mutable)
+	}
+
+	sharedGas = true
+	tx = newTx()
+	err = cli.ResolveSharedInputs(context.Background(), &tx)
+	require.ErrorIs(t, err, ErrSharedObjectAsOwned)
+}
+
+func TestClient_ExecuteMany(t *testing.T) {
+	digest, err := sui_types.NewDigest("HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn")
+	require.NoError(t, err)
+	gas1, gas2, gas3 := SuiAddressNoErr("0x11"), SuiAddressNoErr("0x12"), SuiAddressNoErr("0x13")
+	var submitted int32
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_executeTransactionBlock": func(params []json.RawMessage) any {
+				atomic.AddInt32(&submitted, 1)
+				var txBytes lib.Base64Data
+				require.NoError(t, json.Unmarshal(params[0], &txBytes))
+				tx, err := sui_types.DecodeTransactionData(txBytes)
+				require.NoError(t, err)
+				if tx.V1.GasData.Payment[0].ObjectId == *gas3 {
+					return &jsonError{
+						Code: -32002,
+						Message: "Transaction is rejected as invalid by more than 1/3 of validators by stake " +
+							"(non-retriable). ObjectLockConflict",
+					}
+				}
+				return `{"digest":"` + sui_types.ComputeTransactionDigest(txBytes).String() + `"}`
+			},
+		},
+	)
+
+	newTx := func(gas *suiObjectID) SignedTransaction {
+		tx := sui_types.NewProgrammable(
+			*Address, []*sui_types.ObjectRef{{ObjectId: *gas, Version: 3, Digest: *digest}},
+			sui_types.NewProgrammableTransactionBuilder().Finish(), 1000, 1000,
+		)
+		txBytes, err := tx.TransactionBytes()
+		require.NoError(t, err)
+		return SignedTransaction{TxBytes: txBytes, Signatures: []any{"AA=="}}
+	}
+	txs := []SignedTransaction{newTx(gas1), newTx(gas2), newTx(gas1), newTx(gas3)}
+
+	results, err := cli.ExecuteMany(context.Background(), txs, 2, nil, "")
+	require.ErrorIs(t, err, ErrObjectLockConflict)
+	require.Len(t, results, 4)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, results[i].Err)
+		require.Equal(t, sui_types.ComputeTransactionDigest(txs[i].TxBytes), results[i].Response.Digest)
+	}
+	// the 3rd transaction is not submitted since it uses the gas coin of the 1st one
+	require.ErrorIs(t, results[2].Err, ErrObjectLockConflict)
+	require.ErrorIs(t, results[3].Err, ErrObjectLockConflict)
+	require.Equal(t, int32(3), atomic.LoadInt32(&submitted))
+}
+
+// newEchoObjectsNode responds to sui_multiGetObjects with the requested objects at version 9, which are SUI coins
+func newEchoObjectsNode(t *testing.T) *Client {
+	return newFakeNode(
+		t, map[string]any{
+			"sui_multiGetObjects": func(params []json.RawMessage) any {
+				var ids []sui_types.ObjectID
+				require.NoError(t, json.Unmarshal(params[0], &ids))
+				result := make([]string, 0, len(ids))
+				for _, id := range ids {
+					result = append(
+						result, `{"data":{"objectId":"`+id.String()+`","version":"9",`+
+							`"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn","type":"0x2::coin::Coin<0x2::sui::SUI>"}}`,
+					)
+				}
+				return `[` + strings.Join(result, ",") + `]`
+			},
+		},
+	)
+}
+
+func TestClient_RefreshObjectRefs(t *testing.T) {
+	cli := newEchoObjectsNode(t)
+
+	digest, err := sui_types.NewDigest("HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn")
+	require.NoError(t, err)
+	// more owned inputs than a single multiGetObjects accepts
+	var objects []*sui_types.ObjectRef
+	for i := 0; i < QUERY_MAX_RESULT_LIMIT_OBJECTS+10; i++ {
+		id := sui_types.ObjectID{31: byte(i)}
+		objects = append(objects, &sui_types.ObjectRef{ObjectId: id, Version: 3, Digest: *digest})
+	}
+	ptb := sui_types.NewProgrammableTransactionBuilder()
+	require.NoError(t, ptb.TransferObject(*Address, objects))
+	gas := &sui_types.ObjectRef{ObjectId: sui_types.ObjectID{29: 1}, Version: 3, Digest: *digest}
+	tx := sui_types.NewProgrammable(*Address, []*sui_types.ObjectRef{gas}, ptb.Finish(), 1000, 1000)
+
+	require.NoError(t, cli.refreshObjectRefs(context.Background(), &tx))
+	require.Equal(t, sui_types.SequenceNumber(9), tx.V1.GasData.Payment[0].Version)
+	owned := 0
+	for _, input := range tx.V1.Kind.ProgrammableTransaction.Inputs {
+		if input.Object != nil {
+			require.Equal(t, sui_types.SequenceNumber(9), input.Object.ImmOrOwnedObject.Version)
+			owned++
+		}
+	}
+	require.Equal(t, len(objects), owned)
+}
+
+func TestClient_ValidateGasCoins(t *testing.T) {
+	cli := newEchoObjectsNode(t)
+
+	digest, err := sui_types.NewDigest("HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn")
+	require.NoError(t, err)
+	var payment []*sui_types.ObjectRef
+	for i := 0; i < QUERY_MAX_RESULT_LIMIT_OBJECTS+10; i++ {
+		id := sui_types.ObjectID{31: byte(i)}
+		payment = append(payment, &sui_types.ObjectRef{ObjectId: id, Version: 3, Digest: *digest})
+	}
+	require.NoError(t, cli.ValidateGasCoins(context.Background(), payment))
+
+	payment[1] = nil
+	require.ErrorContains(t, cli.ValidateGasCoins(context.Background(), payment), "gas payment 1 is nil")
+}
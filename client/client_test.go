@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeNode dials a fake node answering the JSON-RPC methods, an unexpected method fails the test.
+// The answer of a method is either:
+//   - string: the raw JSON of the result
+//   - *jsonError: the error of the response
+//   - int: the HTTP status code of the response without a body
+//   - func(params []json.RawMessage) any: called with the params of each request, returns one of the above
+func newFakeNode(t *testing.T, methods map[string]any) *Client {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Method string            `json:"method"`
+					Params []json.RawMessage `json:"params"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				answer, ok := methods[req.Method]
+				if !ok {
+					t.Errorf("unexpected method %v", req.Method)
+					answer = &jsonError{Code: -32601, Message: "Method not found"}
+				}
+				if fn, ok := answer.(func(params []json.RawMessage) any); ok {
+					answer = fn(req.Params)
+				}
+				switch answer := answer.(type) {
+				case string:
+					_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + answer + `}`))
+				case *jsonError:
+					data, err := json.Marshal(answer)
+					require.NoError(t, err)
+					_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":` + string(data) + `}`))
+				case int:
+					w.WriteHeader(answer)
+				default:
+					t.Errorf("unsupported answer %T of %v", answer, req.Method)
+				}
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+	cli, err := Dial(server.URL)
+	require.NoError(t, err)
+	return cli
+}
+
+func TestDial_WithExpectedNetwork(t *testing.T) {
+	rpcUrl := newFakeNode(t, map[string]any{"sui_getChainIdentifier": `"4c78adac"`}).rpcUrl
+
+	_, err := Dial(rpcUrl, WithExpectedNetwork(NetworkTestnet))
+	require.NoError(t, err)
+	_, err = Dial(rpcUrl, WithExpectedNetwork(NetworkMainnet))
+	require.ErrorIs(t, err, ErrNetworkMismatch)
+	_, err = Dial(rpcUrl)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = DialContext(ctx, rpcUrl, WithExpectedNetwork(NetworkTestnet))
+	require.ErrorIs(t, err, context.Canceled)
+}
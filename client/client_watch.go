@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// DefaultWatchInterval the default interval between the polls of WatchObject
+const DefaultWatchInterval = 2 * time.Second
+
+// ObjectUpdate a new version of the watched object
+type ObjectUpdate struct {
+	Ref sui_types.ObjectRef
+	// Transaction the transaction which changed the object, empty for the first update which is the current object
+	Transaction sui_types.TransactionDigest
+	// Deleted the object is deleted or wrapped, Ref.Version is the version of the deletion and it is the last update
+	Deleted bool
+	// Err the poll failed, the other fields are empty, the watcher keeps polling at the next interval
+	Err error
+}
+
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	interval time.Duration
+}
+
+// WithWatchInterval the interval between the polls, default is DefaultWatchInterval
+func WithWatchInterval(interval time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.interval = interval
+	}
+}
+
+// WatchObject watches the changes of the object by polling the transactions with the ChangedObject filter.
+// The first update is the current version of the object, then every new version is sent in order, the channel is
+// closed after the object is deleted or wrapped, or ctx is done.
+// NOTE: the updates are not sent until received, so a slow receiver delays the polls instead of missing versions
+func (c *Client) WatchObject(ctx context.Context, objID suiObjectID, opts ...WatchOption) (<-chan ObjectUpdate, error) {
+	watchOpts := watchOptions{interval: DefaultWatchInterval}
+	for _, opt := range opts {
+		opt(&watchOpts)
+	}
+	if watchOpts.interval <= 0 {
+		return nil, fmt.Errorf("watch interval should be positive, got %v", watchOpts.interval)
+	}
+
+	// the cursor is fetched before the object, so the transactions after the cursor include any newer version
+	query := types.SuiTransactionBlockResponseQuery{
+		Filter: &types.TransactionFilter{ChangedObject: &objID},
+	}
+	limit := uint(1)
+	latest, err := c.QueryTransactionBlocks(ctx, query, nil, &limit, true)
+	if err != nil {
+		return nil, err
+	}
+	var cursor *suiDigest
+	if len(latest.Data) > 0 {
+		cursor = &latest.Data[0].Digest
+	}
+	digest, version, err := c.GetObjectDigest(ctx, objID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan ObjectUpdate)
+	go func() {
+		defer close(updates)
+		send := func(update ObjectUpdate) bool {
+			select {
+			case updates <- update:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		if !send(ObjectUpdate{Ref: sui_types.ObjectRef{ObjectId: objID, Version: version, Digest: digest}}) {
+			return
+		}
+
+		query.Options = &types.SuiTransactionBlockResponseOptions{ShowEffects: true}
+		ticker := time.NewTicker(watchOpts.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+			for {
+				page, err := c.QueryTransactionBlocks(ctx, query, cursor, nil, false)
+				if err != nil {
+					if ctx.Err() != nil || !send(ObjectUpdate{Err: err}) {
+						return
+					}
+					break
+				}
+				for i := range page.Data {
+					tx := &page.Data[i]
+					cursor = &tx.Digest
+					if tx.Effects == nil || tx.Effects.Data.V1 == nil {
+						continue
+					}
+					update, ok := objectUpdateFromEffects(objID, tx.Effects.Data.V1)
+					if !ok || update.Ref.Version <= version {
+						continue
+					}
+					update.Transaction = tx.Digest
+					version = update.Ref.Version
+					if !send(update) || update.Deleted {
+						return
+					}
+				}
+				if !page.HasNextPage || len(page.Data) == 0 {
+					break
+				}
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// objectUpdateFromEffects finds the new ref of the object in the effects
+func objectUpdateFromEffects(objID suiObjectID, effects *types.SuiTransactionBlockEffectsV1) (ObjectUpdate, bool) {
+	for _, refs := range [][]types.OwnedObjectRef{effects.Created, effects.Mutated, effects.Unwrapped} {
+		for _, ref := range refs {
+			if objectRef, err := ref.Reference.ObjectRef(); err == nil && objectRef.ObjectId == objID {
+				return ObjectUpdate{Ref: *objectRef}, true
+			}
+		}
+	}
+	for _, refs := range [][]types.SuiObjectRef{effects.Deleted, effects.Wrapped, effects.UnwrappedThenDeleted} {
+		for _, ref := range refs {
+			if objectRef, err := ref.ObjectRef(); err == nil && objectRef.ObjectId == objID {
+				return ObjectUpdate{Ref: *objectRef, Deleted: true}, true
+			}
+		}
+	}
+	return ObjectUpdate{}, false
+}
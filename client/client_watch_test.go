@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	watchCursorDigest = "11111111111111111111111111111111"
+	watchTxDigest     = "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"
+)
+
+func watchTxResponse(effects string) string {
+	return `{"data":[{"digest":"` + watchTxDigest + `","effects":` + effects + `}],` +
+		`"nextCursor":"` + watchTxDigest + `","hasNextPage":false}`
+}
+
+func TestClient_WatchObject(t *testing.T) {
+	var polls []json.RawMessage
+	cli := newFakeNode(
+		t, map[string]any{
+			"sui_getObject": `{"data":{"objectId":"0x5","version":"5","digest":"` + watchTxDigest + `"}}`,
+			"suix_queryTransactionBlocks": func(params []json.RawMessage) any {
+				if string(params[3]) == "true" {
+					return `{"data":[{"digest":"` + watchCursorDigest + `"}],"hasNextPage":true}`
+				}
+				polls = append(polls, params[1])
+				switch len(polls) {
+				case 1:
+					return `{"data":[],"hasNextPage":false}`
+				case 2:
+					// the stale version is skipped, the mutated version is sent
+					return watchTxResponse(
+						`{"messageVersion":"v1","status":{"status":"success"},"mutated":[` +
+							`{"owner":{"AddressOwner":"0x1"},"reference":{"objectId":"0x9","version":6,"digest":"` + watchTxDigest + `"}},` +
+							`{"owner":{"AddressOwner":"0x1"},"reference":{"objectId":"0x5","version":6,"digest":"` + watchTxDigest + `"}}]}`,
+					)
+				case 3:
+					return &jsonError{Code: -32000, Message: "busy"}
+				default:
+					return watchTxResponse(
+						`{"messageVersion":"v1","status":{"status":"success"},"deleted":[` +
+							`{"objectId":"0x5","version":7,"digest":"` + watchTxDigest + `"}]}`,
+					)
+				}
+			},
+		},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	objID := sui_types.ObjectID{31: 5}
+	updates, err := cli.WatchObject(ctx, objID, WithWatchInterval(10*time.Millisecond))
+	require.NoError(t, err)
+
+	var received []ObjectUpdate
+	for update := range updates {
+		received = append(received, update)
+	}
+	require.NoError(t, ctx.Err())
+	require.Len(t, received, 4)
+
+	require.Equal(t, uint64(5), received[0].Ref.Version)
+	require.Empty(t, received[0].Transaction)
+	require.Equal(t, objID, received[1].Ref.ObjectId)
+	require.Equal(t, uint64(6), received[1].Ref.Version)
+	require.Equal(t, watchTxDigest, received[1].Transaction.String())
+	require.False(t, received[1].Deleted)
+	require.Error(t, received[2].Err)
+	require.Equal(t, uint64(7), received[3].Ref.Version)
+	require.True(t, received[3].Deleted)
+
+	require.Len(t, polls, 4)
+	require.JSONEq(t, `"`+watchCursorDigest+`"`, string(polls[0]))
+	require.JSONEq(t, `"`+watchTxDigest+`"`, string(polls[2]))
+
+	_, err = cli.WatchObject(ctx, objID, WithWatchInterval(0))
+	require.Error(t, err)
+}
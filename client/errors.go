@@ -1,6 +1,41 @@
 package client
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+var (
+	// ErrObjectVersionMismatch the transaction uses an object version which is not the latest one
+	ErrObjectVersionMismatch = errors.New("object version mismatch")
+	// ErrNetworkMismatch the chain identifier of the node is not the expected network
+	ErrNetworkMismatch = errors.New("network mismatch")
+	// ErrLimitExceeded the page size is larger than the max limit of the method
+	ErrLimitExceeded = errors.New("limit exceeded")
+	// ErrObjectNotShared the object is not a shared object
+	ErrObjectNotShared = errors.New("object is not shared")
+	// ErrGasCoinNotSui the gas payment is not a SUI coin
+	ErrGasCoinNotSui = errors.New("gas coin is not a SUI coin")
+	// ErrNotCoin the object is not a `0x2::coin::Coin<T>`
+	ErrNotCoin = errors.New("object is not a coin")
+	// ErrNotCoinMetadata the object is not a `0x2::coin::CoinMetadata<T>`
+	ErrNotCoinMetadata = errors.New("object is not a coin metadata")
+	// ErrObjectDeleted the object has been deleted
+	ErrObjectDeleted = errors.New("object is deleted")
+	// ErrSharedObjectAsOwned a shared object is used as an owned object input, see ResolveSharedInputs
+	ErrSharedObjectAsOwned = errors.New("shared object used as owned input")
+	// ErrObjectLockConflict an owned object of the transaction is locked by another transaction, the object may be
+	// unusable until the end of the epoch if the transactions locked it on different validators
+	ErrObjectLockConflict = errors.New("object lock conflict")
+	// ErrFeatureNotSupported the transaction uses a feature which is not enabled by the protocol version of the network
+	ErrFeatureNotSupported = errors.New("feature not supported by the protocol version")
+	// ErrMethodNotSupported the node doesn't provide the json rpc method, e.g. it is removed in the node version
+	ErrMethodNotSupported = errors.New("method not supported by the node")
+)
 
 type HTTPError struct {
 	StatusCode int
@@ -14,3 +49,118 @@ func (err HTTPError) Error() string {
 	}
 	return fmt.Sprintf("%v: %s", err.Status, err.Body)
 }
+
+// InsufficientBalanceError the balance of the coin type is less than required, see Client.PreflightPayment.
+// It is types.ErrInsufficientBalance by errors.Is
+type InsufficientBalanceError struct {
+	CoinType  string
+	Required  types.SuiBigInt
+	Available types.SuiBigInt
+}
+
+// Shortfall the amount missing from the balance
+func (err *InsufficientBalanceError) Shortfall() types.SuiBigInt {
+	return err.Required.Sub(err.Available)
+}
+
+func (err *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf(
+		"%v: %v of %v is required, the balance is %v, short of %v", types.ErrInsufficientBalance, err.Required,
+		err.CoinType, err.Available, err.Shortfall(),
+	)
+}
+
+func (err *InsufficientBalanceError) Unwrap() error {
+	return types.ErrInsufficientBalance
+}
+
+// UnsupportedFeatureError the feature of the transaction is not enabled at the protocol version of the network,
+// see Client.CheckProtocolSupport. It is ErrFeatureNotSupported by errors.Is
+type UnsupportedFeatureError struct {
+	Feature         sui_types.ProtocolFeature
+	ProtocolVersion uint64
+	// RequiredVersion the first protocol version enabling the feature, 0 if no version supported by the node enables it
+	RequiredVersion uint64
+}
+
+func (err *UnsupportedFeatureError) Error() string {
+	if err.RequiredVersion == 0 {
+		return fmt.Sprintf(
+			"%v: %v (feature flag %v) is not enabled by any protocol version supported by the node, the network is at %d",
+			ErrFeatureNotSupported, err.Feature.Name, err.Feature.FeatureFlag, err.ProtocolVersion,
+		)
+	}
+	return fmt.Sprintf(
+		"%v: %v (feature flag %v) requires protocol version %d, the network is at %d", ErrFeatureNotSupported,
+		err.Feature.Name, err.Feature.FeatureFlag, err.RequiredVersion, err.ProtocolVersion,
+	)
+}
+
+func (err *UnsupportedFeatureError) Unwrap() error {
+	return ErrFeatureNotSupported
+}
+
+// isMoveFunctionNotFound the node can't find the package, module or function of getNormalizedMoveFunction
+func isMoveFunctionNotFound(err error) bool {
+	var rpcErr *jsonError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return strings.Contains(rpcErr.Message, "does not exist") ||
+		strings.Contains(rpcErr.Message, "No module found") ||
+		strings.Contains(rpcErr.Message, "No module was found") ||
+		strings.Contains(rpcErr.Message, "No function was found")
+}
+
+func isObjectVersionMismatch(err error) bool {
+	var rpcErr *jsonError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return strings.Contains(rpcErr.Message, "is not available for consumption") ||
+		strings.Contains(rpcErr.Message, "ObjectVersionUnavailableForConsumption")
+}
+
+// isSharedObjectAsOwned the node rejects the owned object input which is a shared object with NotOwnedObjectError
+func isSharedObjectAsOwned(err error) bool {
+	var rpcErr *jsonError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return strings.Contains(rpcErr.Message, "Object used as owned is not owned") ||
+		strings.Contains(rpcErr.Message, "NotOwnedObjectError")
+}
+
+// isObjectLockConflict the node rejects the transaction since its owned objects are locked by another transaction
+func isObjectLockConflict(err error) bool {
+	var rpcErr *jsonError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return strings.Contains(rpcErr.Message, "ObjectLockConflict") ||
+		strings.Contains(rpcErr.Message, "already locked by a different transaction") ||
+		strings.Contains(rpcErr.Message, "equivocated")
+}
+
+// isMethodNotFound the node returns the json rpc error -32601 for an unknown method
+func isMethodNotFound(err error) bool {
+	var rpcErr *jsonError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return rpcErr.Code == -32601 || strings.Contains(rpcErr.Message, "Method not found")
+}
+
+func checkLimit(method Method, limit uint, max uint) error {
+	if limit > max {
+		return fmt.Errorf("%w: %v accepts at most %d, got %d", ErrLimitExceeded, method, max, limit)
+	}
+	return nil
+}
+
+func checkLimitPtr(method Method, limit *uint, max uint) error {
+	if limit == nil {
+		return nil
+	}
+	return checkLimit(method, *limit, max)
+}
@@ -32,16 +32,19 @@ const (
 	devInspectTransactionBlock        SuiMethod    = "devInspectTransactionBlock"
 	dryRunTransactionBlock            SuiMethod    = "dryRunTransactionBlock"
 	executeTransactionBlock           SuiMethod    = "executeTransactionBlock"
+	getChainIdentifier                SuiMethod    = "getChainIdentifier"
 	getCheckpoint                     SuiMethod    = "getCheckpoint"
 	getCheckpoints                    SuiMethod    = "getCheckpoints"
 	getEvents                         SuiMethod    = "getEvents"
 	getLatestCheckpointSequenceNumber SuiMethod    = "getLatestCheckpointSequenceNumber"
+	getLoadedChildObjects             SuiMethod    = "getLoadedChildObjects"
 	getMoveFunctionArgTypes           SuiMethod    = "getMoveFunctionArgTypes"
 	getNormalizedMoveFunction         SuiMethod    = "getNormalizedMoveFunction"
 	getNormalizedMoveModule           SuiMethod    = "getNormalizedMoveModule"
 	getNormalizedMoveModulesByPackage SuiMethod    = "getNormalizedMoveModulesByPackage"
 	getNormalizedMoveStruct           SuiMethod    = "getNormalizedMoveStruct"
 	getObject                         SuiMethod    = "getObject"
+	getProtocolConfig                 SuiMethod    = "getProtocolConfig"
 	getTotalTransactionBlocks         SuiMethod    = "getTotalTransactionBlocks"
 	getTransactionBlock               SuiMethod    = "getTransactionBlock"
 	multiGetObjects                   SuiMethod    = "multiGetObjects"
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coming-chat/go-sui/v2/types"
+)
+
+// DefaultMaxPages is used by CollectAllPages when maxPages is 0
+const DefaultMaxPages = 100
+
+var ErrTooManyPages = errors.New("reached the max number of pages before the last page")
+
+// PageFetcher fetches the page after cursor, the first page when cursor is nil
+type PageFetcher[T types.PageData, C types.PageCursor] func(ctx context.Context, cursor *C) (*types.Page[T, C], error)
+
+// CollectAllPages keeps fetching pages until there is no next page.
+// @param maxPages the max number of pages to fetch, default is `DefaultMaxPages`
+// @throw ErrTooManyPages If there are still more pages after fetching maxPages, the data already fetched is returned with it
+func CollectAllPages[T types.PageData, C types.PageCursor](
+	ctx context.Context,
+	fetch PageFetcher[T, C],
+	maxPages int,
+) ([]T, error) {
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	var (
+		data   []T
+		cursor *C
+	)
+	for i := 0; i < maxPages; i++ {
+		page, err := fetch(ctx, cursor)
+		if err != nil {
+			return data, err
+		}
+		data = append(data, page.Data...)
+		if !page.HasNextPage || page.NextCursor == nil {
+			return data, nil
+		}
+		cursor = page.NextCursor
+	}
+	return data, ErrTooManyPages
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeNamePages(total int) PageFetcher[string, string] {
+	return func(ctx context.Context, cursor *string) (*types.Page[string, string], error) {
+		start := 0
+		if cursor != nil {
+			start, _ = strconv.Atoi(*cursor)
+		}
+		next := strconv.Itoa(start + 1)
+		return &types.Page[string, string]{
+			Data:        []string{strconv.Itoa(start)},
+			NextCursor:  &next,
+			HasNextPage: start+1 < total,
+		}, nil
+	}
+}
+
+func TestCollectAllPages(t *testing.T) {
+	data, err := CollectAllPages(context.Background(), fakeNamePages(3), 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0", "1", "2"}, data)
+
+	data, err = CollectAllPages(context.Background(), fakeNamePages(3), 2)
+	require.ErrorIs(t, err, ErrTooManyPages)
+	require.Equal(t, []string{"0", "1"}, data)
+}
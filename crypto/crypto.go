@@ -1,5 +1,10 @@
 package crypto
 
+import "errors"
+
+// ErrKeyPairClosed the private key is zeroed by Zero
+var ErrKeyPairClosed = errors.New("key pair is closed")
+
 type Signer[T any] interface {
 	Sign(msg []byte) T
 }
@@ -11,6 +11,7 @@ type KeyPair interface {
 type Ed25519KeyPair struct {
 	privateKey ed25519.PrivateKey
 	publicKey  ed25519.PublicKey
+	closed     bool
 }
 
 func NewEd25519KeyPair(privateKey ed25519.PrivateKey) *Ed25519KeyPair {
@@ -20,8 +21,21 @@ func NewEd25519KeyPair(privateKey ed25519.PrivateKey) *Ed25519KeyPair {
 	}
 }
 
+// Sign panics if the key pair is zeroed by Zero, use TrySign to get the error instead
 func (e *Ed25519KeyPair) Sign(msg []byte) []byte {
-	return ed25519.Sign(e.privateKey, msg)
+	sig, err := e.TrySign(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+// TrySign returns ErrKeyPairClosed if the key pair is zeroed by Zero
+func (e *Ed25519KeyPair) TrySign(msg []byte) ([]byte, error) {
+	if e.closed {
+		return nil, ErrKeyPairClosed
+	}
+	return ed25519.Sign(e.privateKey, msg), nil
 }
 
 func (e *Ed25519KeyPair) PublicKey() []byte {
@@ -31,3 +45,24 @@ func (e *Ed25519KeyPair) PublicKey() []byte {
 func (e *Ed25519KeyPair) PrivateKey() []byte {
 	return e.privateKey
 }
+
+// Zero overwrites the private key with zeros, the key pair can't be used afterwards
+func (e *Ed25519KeyPair) Zero() {
+	for i := range e.privateKey {
+		e.privateKey[i] = 0
+	}
+	e.closed = true
+}
+
+// Closed reports whether the private key is zeroed by Zero
+func (e *Ed25519KeyPair) Closed() bool {
+	return e.closed
+}
+
+// VerifyEd25519 verifies the signature of msg, the invalid public key is rejected instead of panic
+func VerifyEd25519(publicKey []byte, msg []byte, signature []byte) bool {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(publicKey, msg, signature)
+}
@@ -0,0 +1,196 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// Bn254FieldModulus the scalar field of BN254, which is the field of Poseidon in circomlib and zkLogin
+var Bn254FieldModulus, _ = new(big.Int).SetString(
+	"21888242871839275222246405745257275088548364400416034343698204186575808495617", 10,
+)
+
+const (
+	poseidonMaxInputs   = 16
+	poseidonFullRounds  = 8
+	poseidonFieldBits   = 254
+	poseidonGrainLength = 80
+)
+
+// poseidonPartialRounds the partial rounds of circomlib for t = 2..17
+var poseidonPartialRounds = []int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68}
+
+type poseidonParams struct {
+	roundConstants []*big.Int
+	mds            [][]*big.Int
+}
+
+var (
+	poseidonParamsOnce  [poseidonMaxInputs]sync.Once
+	poseidonParamsCache [poseidonMaxInputs]*poseidonParams
+)
+
+// PoseidonHash the Poseidon hash of circomlib over BN254 with 1 to 16 inputs, each input must be in the field.
+// The constants are generated by the Grain LFSR as the reference script of the Poseidon paper,
+// which is how the constants of circomlib were generated
+func PoseidonHash(inputs []*big.Int) (*big.Int, error) {
+	if len(inputs) == 0 || len(inputs) > poseidonMaxInputs {
+		return nil, fmt.Errorf("poseidon takes 1 to %d inputs, got %d", poseidonMaxInputs, len(inputs))
+	}
+	t := len(inputs) + 1
+	state := make([]*big.Int, t)
+	state[0] = new(big.Int)
+	for i, input := range inputs {
+		if input.Sign() < 0 || input.Cmp(Bn254FieldModulus) >= 0 {
+			return nil, errors.New("poseidon input is not in the field")
+		}
+		state[i+1] = new(big.Int).Set(input)
+	}
+
+	params := getPoseidonParams(t)
+	partialRounds := poseidonPartialRounds[t-2]
+	tmp := new(big.Int)
+	for r := 0; r < poseidonFullRounds+partialRounds; r++ {
+		for i := range state {
+			state[i].Add(state[i], params.roundConstants[r*t+i])
+			state[i].Mod(state[i], Bn254FieldModulus)
+		}
+		if r < poseidonFullRounds/2 || r >= poseidonFullRounds/2+partialRounds {
+			for i := range state {
+				pow5(state[i])
+			}
+		} else {
+			pow5(state[0])
+		}
+		mixed := make([]*big.Int, t)
+		for i := range mixed {
+			mixed[i] = new(big.Int)
+			for j := range state {
+				mixed[i].Add(mixed[i], tmp.Mul(params.mds[i][j], state[j]))
+			}
+			mixed[i].Mod(mixed[i], Bn254FieldModulus)
+		}
+		state = mixed
+	}
+	return state[0], nil
+}
+
+func pow5(x *big.Int) {
+	square := new(big.Int).Mul(x, x)
+	square.Mod(square, Bn254FieldModulus)
+	quad := new(big.Int).Mul(square, square)
+	quad.Mod(quad, Bn254FieldModulus)
+	x.Mul(x, quad)
+	x.Mod(x, Bn254FieldModulus)
+}
+
+func getPoseidonParams(t int) *poseidonParams {
+	poseidonParamsOnce[t-2].Do(
+		func() {
+			poseidonParamsCache[t-2] = generatePoseidonParams(t)
+		},
+	)
+	return poseidonParamsCache[t-2]
+}
+
+// generatePoseidonParams generates the round constants and the Cauchy MDS matrix of width t
+func generatePoseidonParams(t int) *poseidonParams {
+	partialRounds := poseidonPartialRounds[t-2]
+	grain := newGrainLFSR(1, 0, poseidonFieldBits, t, poseidonFullRounds, partialRounds)
+
+	numConstants := (poseidonFullRounds + partialRounds) * t
+	constants := make([]*big.Int, 0, numConstants)
+	for len(constants) < numConstants {
+		c := grain.randomBits(poseidonFieldBits)
+		if c.Cmp(Bn254FieldModulus) < 0 {
+			constants = append(constants, c)
+		}
+	}
+
+	var mds [][]*big.Int
+	for mds == nil {
+		mds = grain.cauchyMatrix(t)
+	}
+	return &poseidonParams{roundConstants: constants, mds: mds}
+}
+
+type grainLFSR struct {
+	state []byte
+}
+
+func newGrainLFSR(field, sbox, fieldSize, t, fullRounds, partialRounds int) *grainLFSR {
+	g := &grainLFSR{state: make([]byte, 0, poseidonGrainLength)}
+	appendBits := func(value, length int) {
+		for i := length - 1; i >= 0; i-- {
+			g.state = append(g.state, byte(value>>i&1))
+		}
+	}
+	appendBits(field, 2)
+	appendBits(sbox, 4)
+	appendBits(fieldSize, 12)
+	appendBits(t, 12)
+	appendBits(fullRounds, 10)
+	appendBits(partialRounds, 10)
+	appendBits(1<<30-1, 30)
+	for i := 0; i < 160; i++ {
+		g.nextBit()
+	}
+	return g
+}
+
+func (g *grainLFSR) nextBit() byte {
+	s := g.state
+	bit := s[62] ^ s[51] ^ s[38] ^ s[23] ^ s[13] ^ s[0]
+	g.state = append(s[1:], bit)
+	return bit
+}
+
+// outputBit a bit is output only if the bit before it is 1
+func (g *grainLFSR) outputBit() byte {
+	for g.nextBit() == 0 {
+		g.nextBit()
+	}
+	return g.nextBit()
+}
+
+func (g *grainLFSR) randomBits(n int) *big.Int {
+	result := new(big.Int)
+	for i := 0; i < n; i++ {
+		result.Lsh(result, 1)
+		result.SetBit(result, 0, uint(g.outputBit()))
+	}
+	return result
+}
+
+// cauchyMatrix returns M[i][j] = 1 / (x[i] + y[j]), or nil if x[i] + y[j] is 0
+func (g *grainLFSR) cauchyMatrix(t int) [][]*big.Int {
+	var elements []*big.Int
+	for {
+		elements = make([]*big.Int, 2*t)
+		seen := make(map[string]bool)
+		for i := range elements {
+			elements[i] = g.randomBits(poseidonFieldBits)
+			elements[i].Mod(elements[i], Bn254FieldModulus)
+			seen[elements[i].String()] = true
+		}
+		if len(seen) == len(elements) {
+			break
+		}
+	}
+	xs, ys := elements[:t], elements[t:]
+	mds := make([][]*big.Int, t)
+	for i := range mds {
+		mds[i] = make([]*big.Int, t)
+		for j := range mds[i] {
+			sum := new(big.Int).Add(xs[i], ys[j])
+			sum.Mod(sum, Bn254FieldModulus)
+			if sum.Sign() == 0 {
+				return nil
+			}
+			mds[i][j] = sum.ModInverse(sum, Bn254FieldModulus)
+		}
+	}
+	return mds
+}
@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoseidonHash(t *testing.T) {
+	tests := []struct {
+		inputs []int64
+		want   string
+	}{
+		{inputs: []int64{1}, want: "18586133768512220936620570745912940619677854269274689475585506675881198879027"},
+		{inputs: []int64{1, 2}, want: "7853200120776062878684798364095072458815029376092732009249414926327459813530"},
+		// the widths hashing the zkLogin address seed and the padded aud
+		{inputs: []int64{1, 2, 3, 4}, want: "18821383157269793795438455681495246036402687001665670618754263018637548127333"},
+		{inputs: []int64{1, 2, 0, 0, 0}, want: "1018317224307729531995786483840663576608797660851238720571059489595066344487"},
+	}
+	for _, tt := range tests {
+		inputs := make([]*big.Int, len(tt.inputs))
+		for i, input := range tt.inputs {
+			inputs[i] = big.NewInt(input)
+		}
+		got, err := PoseidonHash(inputs)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got.String())
+	}
+}
+
+func TestGeneratePoseidonParams(t *testing.T) {
+	// the first constants of circomlib
+	require.Equal(t, "9c46e9ec68e9bd4fe1faaba294cba38a71aa177534cdd1b6c7dc0dbd0abd7a7", getPoseidonParams(2).roundConstants[0].Text(16))
+	require.Equal(t, "ee9a592ba9a9518d05986d656f40c2114c4993c11bb29938d21d47304cd8e6e", getPoseidonParams(3).roundConstants[0].Text(16))
+	require.Equal(t, "109b7f411ba0e4c9b2b70caf5c36a7b194be7c11ad24378bfedb68592ba8118b", getPoseidonParams(3).mds[0][0].Text(16))
+
+	_, err := PoseidonHash(nil)
+	require.Error(t, err)
+	_, err = PoseidonHash([]*big.Int{Bn254FieldModulus})
+	require.Error(t, err)
+}
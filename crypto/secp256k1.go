@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"crypto/sha256"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+type Secp256k1KeyPair struct {
+	privateKey *secp256k1.PrivateKey
+	publicKey  []byte
+	closed     bool
+}
+
+// NewSecp256k1KeyPair the public key is in compressed form
+func NewSecp256k1KeyPair(privateKey []byte) *Secp256k1KeyPair {
+	key := secp256k1.PrivKeyFromBytes(privateKey)
+	return &Secp256k1KeyPair{
+		privateKey: key,
+		publicKey:  key.PubKey().SerializeCompressed(),
+	}
+}
+
+// Sign returns the 64 bytes r || s signature of sha256(msg), s is normalized to the lower half.
+// It panics if the key pair is zeroed by Zero, use TrySign to get the error instead
+func (k *Secp256k1KeyPair) Sign(msg []byte) []byte {
+	sig, err := k.TrySign(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+// TrySign returns ErrKeyPairClosed if the key pair is zeroed by Zero
+func (k *Secp256k1KeyPair) TrySign(msg []byte) ([]byte, error) {
+	if k.closed {
+		return nil, ErrKeyPairClosed
+	}
+	hash := sha256.Sum256(msg)
+	// the first byte is the recovery code
+	return ecdsa.SignCompact(k.privateKey, hash[:], true)[1:], nil
+}
+
+func (k *Secp256k1KeyPair) PublicKey() []byte {
+	return k.publicKey
+}
+
+func (k *Secp256k1KeyPair) PrivateKey() []byte {
+	return k.privateKey.Serialize()
+}
+
+// Zero overwrites the private key with zeros, the key pair can't be used afterwards
+func (k *Secp256k1KeyPair) Zero() {
+	k.privateKey.Zero()
+	k.closed = true
+}
+
+// Closed reports whether the private key is zeroed by Zero
+func (k *Secp256k1KeyPair) Closed() bool {
+	return k.closed
+}
+
+// VerifySecp256k1 verifies the 64 bytes r || s signature of sha256(msg), signatures with a high s are rejected
+func VerifySecp256k1(publicKey []byte, msg []byte, signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+	key, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return false
+	}
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) || s.IsOverHalfOrder() {
+		return false
+	}
+	hash := sha256.Sum256(msg)
+	return ecdsa.NewSignature(&r, &s).Verify(hash[:], key)
+}
@@ -0,0 +1,99 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+)
+
+type Secp256r1KeyPair struct {
+	privateKey *ecdsa.PrivateKey
+	publicKey  []byte
+	closed     bool
+}
+
+// NewSecp256r1KeyPair the public key is in compressed form
+func NewSecp256r1KeyPair(privateKey []byte) *Secp256r1KeyPair {
+	curve := elliptic.P256()
+	key := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(privateKey)}
+	key.PublicKey.Curve = curve
+	key.PublicKey.X, key.PublicKey.Y = curve.ScalarBaseMult(key.D.Bytes())
+	return &Secp256r1KeyPair{
+		privateKey: key,
+		publicKey:  elliptic.MarshalCompressed(curve, key.PublicKey.X, key.PublicKey.Y),
+	}
+}
+
+// Sign returns the 64 bytes r || s signature of sha256(msg), s is normalized to the lower half.
+// It panics if the key pair is zeroed by Zero or the signing fails, use TrySign to get the error instead
+func (k *Secp256r1KeyPair) Sign(msg []byte) []byte {
+	sig, err := k.TrySign(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+// TrySign returns ErrKeyPairClosed if the key pair is zeroed by Zero, and the error of ecdsa.Sign
+func (k *Secp256r1KeyPair) TrySign(msg []byte) ([]byte, error) {
+	if k.closed {
+		return nil, ErrKeyPairClosed
+	}
+	hash := sha256.Sum256(msg)
+	r, s, err := ecdsa.Sign(rand.Reader, k.privateKey, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	n := k.privateKey.Curve.Params().N
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s = new(big.Int).Sub(n, s)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig, nil
+}
+
+func (k *Secp256r1KeyPair) PublicKey() []byte {
+	return k.publicKey
+}
+
+func (k *Secp256r1KeyPair) PrivateKey() []byte {
+	return k.privateKey.D.FillBytes(make([]byte, 32))
+}
+
+// Zero overwrites the words of the private key with zeros, the key pair can't be used afterwards.
+// NOTE: the copies made by math/big during the computations can't be erased
+func (k *Secp256r1KeyPair) Zero() {
+	words := k.privateKey.D.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	k.privateKey.D.SetInt64(0)
+	k.closed = true
+}
+
+// Closed reports whether the private key is zeroed by Zero
+func (k *Secp256r1KeyPair) Closed() bool {
+	return k.closed
+}
+
+// VerifySecp256r1 verifies the 64 bytes r || s signature of sha256(msg), signatures with a high s are rejected
+func VerifySecp256r1(publicKey []byte, msg []byte, signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+	curve := elliptic.P256()
+	x, y := elliptic.UnmarshalCompressed(curve, publicKey)
+	if x == nil {
+		return false
+	}
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if s.Cmp(new(big.Int).Rsh(curve.Params().N, 1)) > 0 {
+		return false
+	}
+	hash := sha256.Sum256(msg)
+	return ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, hash[:], r, s)
+}
@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/btcsuite/btcutil v1.0.2
 	github.com/coming-chat/go-aptos v0.0.0-20221013022715-39f91035c785
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
 	github.com/fardream/go-bcs v0.4.0
 	github.com/mitchellh/hashstructure/v2 v2.0.2
 	github.com/shopspring/decimal v1.3.1
@@ -0,0 +1,212 @@
+package lib
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// UnmarshalBcs decodes the BCS bytes into v as bcs.Unmarshal does, and returns the number of the bytes decoded.
+// bcs.Unmarshal fails with "trying to decode into nil pointer/interface" for a variant which is a pointer to another
+// enum, e.g. `Object *ObjectArg` of CallArg, the variant is allocated before decoding here.
+func UnmarshalBcs(data []byte, v any) (int, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return 0, fmt.Errorf("not a pointer or nil pointer")
+	}
+	d := bcsDecoder{reader: bytes.NewReader(data)}
+	return d.decode(value)
+}
+
+type bcsDecoder struct {
+	reader *bytes.Reader
+}
+
+// readLength reads the uleb128 length of a sequence, whose every element takes at least one byte, so a length larger
+// than the bytes left is rejected before allocating
+func (d *bcsDecoder) readLength() (int, int, error) {
+	size, n, err := bcs.ULEB128Decode[int](d.reader)
+	if err != nil {
+		return 0, n, err
+	}
+	if size < 0 || size > d.reader.Len() {
+		return 0, n, fmt.Errorf("length %d exceeds the %d bytes left", size, d.reader.Len())
+	}
+	return size, n, nil
+}
+
+func (d *bcsDecoder) decode(v reflect.Value) (int, error) {
+	if !v.CanInterface() {
+		return 0, nil
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		if !v.CanSet() {
+			return 0, fmt.Errorf("cannot decode into nil pointer of %v", v.Type())
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	if unmarshaler, ok := v.Interface().(bcs.Unmarshaler); ok && v.Kind() == reflect.Pointer {
+		return unmarshaler.UnmarshalBCS(d.reader)
+	}
+	if v.CanAddr() {
+		if unmarshaler, ok := v.Addr().Interface().(bcs.Unmarshaler); ok {
+			return unmarshaler.UnmarshalBCS(d.reader)
+		}
+	}
+	if _, ok := v.Interface().(bcs.Enum); ok {
+		if v.Kind() == reflect.Pointer {
+			return d.decodeEnum(v.Elem())
+		}
+		return d.decodeEnum(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		return d.decode(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0, fmt.Errorf("cannot decode into nil interface")
+		}
+		return d.decode(v.Elem())
+	case reflect.Chan, reflect.Func, reflect.Uintptr, reflect.UnsafePointer:
+		return 0, nil
+	default:
+		return d.decodeVanilla(v)
+	}
+}
+
+func (d *bcsDecoder) decodeVanilla(v reflect.Value) (int, error) {
+	if !v.CanSet() {
+		return 0, fmt.Errorf("cannot change value of kind %v", v.Kind())
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		var b [1]byte
+		n, err := io.ReadFull(d.reader, b[:])
+		if err != nil {
+			return n, err
+		}
+		v.SetBool(b[0] != 0)
+		return n, nil
+	case reflect.Int8, reflect.Uint8:
+		return 1, binary.Read(d.reader, binary.LittleEndian, v.Addr().Interface())
+	case reflect.Int16, reflect.Uint16:
+		return 2, binary.Read(d.reader, binary.LittleEndian, v.Addr().Interface())
+	case reflect.Int32, reflect.Uint32:
+		return 4, binary.Read(d.reader, binary.LittleEndian, v.Addr().Interface())
+	case reflect.Int64, reflect.Uint64:
+		return 8, binary.Read(d.reader, binary.LittleEndian, v.Addr().Interface())
+	case reflect.Struct:
+		return d.decodeStruct(v)
+	case reflect.Slice:
+		return d.decodeSlice(v)
+	case reflect.Array:
+		var n int
+		for i := 0; i < v.Len(); i++ {
+			k, err := d.decode(v.Index(i))
+			n += k
+			if err != nil {
+				return n, err
+			}
+		}
+		return n, nil
+	case reflect.String:
+		data, n, err := d.readBytes()
+		if err != nil {
+			return n, err
+		}
+		v.SetString(string(data))
+		return n, nil
+	default:
+		return 0, fmt.Errorf("unsupported vanilla decoding type: %v", v.Kind())
+	}
+}
+
+func (d *bcsDecoder) decodeStruct(v reflect.Value) (int, error) {
+	var n int
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanInterface() {
+			continue
+		}
+		tag := v.Type().Field(i).Tag.Get("bcs")
+		if tag == "-" {
+			continue
+		}
+		if strings.Contains(tag, "optional") {
+			var isSome [1]byte
+			k, err := io.ReadFull(d.reader, isSome[:])
+			n += k
+			if err != nil {
+				return n, err
+			}
+			if isSome[0] == 0 {
+				field.Set(reflect.Zero(field.Type()))
+				continue
+			}
+		}
+		k, err := d.decode(field)
+		n += k
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (d *bcsDecoder) decodeEnum(v reflect.Value) (int, error) {
+	if v.Kind() != reflect.Struct {
+		return 0, fmt.Errorf("only support struct for Enum, got %v", v.Kind())
+	}
+	variant, n, err := bcs.ULEB128Decode[int](d.reader)
+	if err != nil {
+		return n, err
+	}
+	if variant < 0 || variant >= v.NumField() {
+		return n, fmt.Errorf("invalid variant %d of enum %v", variant, v.Type())
+	}
+	k, err := d.decode(v.Field(variant))
+	return n + k, err
+}
+
+func (d *bcsDecoder) decodeSlice(v reflect.Value) (int, error) {
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		data, n, err := d.readBytes()
+		if err != nil {
+			return n, err
+		}
+		v.SetBytes(data)
+		return n, nil
+	}
+	size, n, err := d.readLength()
+	if err != nil {
+		return n, err
+	}
+	slice := reflect.MakeSlice(v.Type(), size, size)
+	for i := 0; i < size; i++ {
+		k, err := d.decode(slice.Index(i))
+		n += k
+		if err != nil {
+			return n, err
+		}
+	}
+	v.Set(slice)
+	return n, nil
+}
+
+// readBytes reads the uleb128 length and the bytes
+func (d *bcsDecoder) readBytes() ([]byte, int, error) {
+	size, n, err := d.readLength()
+	if err != nil {
+		return nil, n, err
+	}
+	data := make([]byte, size)
+	k, err := io.ReadFull(d.reader, data)
+	return data, n + k, err
+}
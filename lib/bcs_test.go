@@ -0,0 +1,81 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/fardream/go-bcs/bcs"
+	"github.com/stretchr/testify/require"
+)
+
+type testInnerEnum struct {
+	Empty *EmptyEnum
+	Value *uint64
+}
+
+func (e testInnerEnum) IsBcsEnum() {
+}
+
+type testOuterEnum struct {
+	Bytes *[]byte
+	Inner *testInnerEnum
+}
+
+func (e testOuterEnum) IsBcsEnum() {
+}
+
+type testBcsStruct struct {
+	Flag     bool
+	Name     string
+	Values   []testOuterEnum
+	Array    [2]uint16
+	Optional *uint32 `bcs:"optional"`
+	Missing  *uint32 `bcs:"optional"`
+	Big      bcs.Uint128
+	Ignored  string `bcs:"-"`
+}
+
+func TestUnmarshalBcs(t *testing.T) {
+	value := uint64(7)
+	optional := uint32(9)
+	big, err := bcs.NewUint128("340282366920938463463374607431768211455")
+	require.NoError(t, err)
+	expected := testBcsStruct{
+		Flag: true,
+		Name: "name",
+		Values: []testOuterEnum{
+			{Bytes: &[]byte{1, 2}},
+			{Inner: &testInnerEnum{Value: &value}},
+			{Inner: &testInnerEnum{Empty: &EmptyEnum{}}},
+		},
+		Array:    [2]uint16{3, 4},
+		Optional: &optional,
+		Big:      *big,
+	}
+	data, err := bcs.Marshal(expected)
+	require.NoError(t, err)
+
+	// the variants pointing to another enum can not be decoded by bcs.Unmarshal
+	var failed testBcsStruct
+	_, err = bcs.Unmarshal(data, &failed)
+	require.Error(t, err)
+
+	var decoded testBcsStruct
+	n, err := UnmarshalBcs(data, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, len(data), n)
+	require.Equal(t, expected, decoded)
+
+	_, err = UnmarshalBcs(data[:len(data)-1], &decoded)
+	require.Error(t, err)
+	_, err = UnmarshalBcs([]byte{2}, &testOuterEnum{})
+	require.Error(t, err)
+
+	// the length prefix larger than the input is rejected instead of allocating
+	oversized := []byte{0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}
+	_, err = UnmarshalBcs(oversized, &decoded)
+	require.ErrorContains(t, err, "exceeds")
+	_, err = UnmarshalBcs([]byte{3, 1, 2}, &[]uint16{})
+	require.ErrorContains(t, err, "exceeds")
+	_, err = UnmarshalBcs([]byte{3, 1, 2}, &[]byte{})
+	require.ErrorContains(t, err, "exceeds")
+}
@@ -1,14 +1,11 @@
 package lib
 
-import "io"
-
+// EmptyEnum is the variant of a bcs enum without data.
+// NOTE: it should not implement bcs.Unmarshaler, the decoder calls UnmarshalBCS with the nil pointer of an unset
+// variant, so the variant is never allocated. Without the method the pointer is allocated and the empty struct decoded
 type EmptyEnum struct {
 }
 
 func (e EmptyEnum) MarshalBCS() ([]byte, error) {
 	return []byte{}, nil
 }
-
-func (e *EmptyEnum) UnmarshalBCS(io.Reader) (int, error) {
-	return 0, nil
-}
@@ -1,6 +1,14 @@
 package move_types
 
-import "github.com/coming-chat/go-sui/v2/lib"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/fardream/go-bcs/bcs"
+)
 
 type StructTag struct {
 	Address    AccountAddress
@@ -9,6 +17,19 @@ type StructTag struct {
 	TypeParams []TypeTag
 }
 
+// String returns the type in the format of the node, e.g. `0x2::coin::Coin<0x2::sui::SUI>`
+func (s StructTag) String() string {
+	str := fmt.Sprintf("%v::%v::%v", shortHex(s.Address), s.Module, s.Name)
+	if len(s.TypeParams) == 0 {
+		return str
+	}
+	params := make([]string, len(s.TypeParams))
+	for i, param := range s.TypeParams {
+		params[i] = param.String()
+	}
+	return fmt.Sprintf("%v<%v>", str, strings.Join(params, ", "))
+}
+
 type TypeTag struct {
 	Bool    *lib.EmptyEnum
 	U8      *lib.EmptyEnum
@@ -25,3 +46,223 @@ type TypeTag struct {
 
 func (t TypeTag) IsBcsEnum() {
 }
+
+// ParseTypeTag parses the type string, e.g. `u64`, `vector<u8>` or `0x2::coin::Coin<0x2::sui::SUI>`
+func ParseTypeTag(str string) (*TypeTag, error) {
+	p := typeTagParser{str: str}
+	tag, err := p.typeTag()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos != len(p.str) {
+		return nil, fmt.Errorf("invalid type tag %v: unexpected %q", str, p.str[p.pos:])
+	}
+	return tag, nil
+}
+
+// ParseStructTag parses the struct type string, e.g. `0x2::coin::Coin<0x2::sui::SUI>`
+func ParseStructTag(str string) (*StructTag, error) {
+	tag, err := ParseTypeTag(str)
+	if err != nil {
+		return nil, err
+	}
+	if tag.Struct == nil {
+		return nil, fmt.Errorf("%v is not a struct type", str)
+	}
+	return tag.Struct, nil
+}
+
+func (t TypeTag) String() string {
+	switch {
+	case t.Bool != nil:
+		return "bool"
+	case t.U8 != nil:
+		return "u8"
+	case t.U16 != nil:
+		return "u16"
+	case t.U32 != nil:
+		return "u32"
+	case t.U64 != nil:
+		return "u64"
+	case t.U128 != nil:
+		return "u128"
+	case t.U256 != nil:
+		return "u256"
+	case t.Address != nil:
+		return "address"
+	case t.Signer != nil:
+		return "signer"
+	case t.Vector != nil:
+		return fmt.Sprintf("vector<%v>", t.Vector.String())
+	case t.Struct != nil:
+		return t.Struct.String()
+	default:
+		return ""
+	}
+}
+
+// BCSBytes returns the BCS bytes of the type tag, which the chain uses for the type equality. The addresses are
+// always 32 bytes, so the tags parsed from the semantically equal strings, e.g. with different address padding or
+// spaces, have identical bytes, and the bytes can be used as the map key of the type identity
+func (t TypeTag) BCSBytes() ([]byte, error) {
+	if t.String() == "" {
+		return nil, errors.New("empty type tag")
+	}
+	return bcs.Marshal(t)
+}
+
+// MarshalJSON the type arguments of the json rpc are type strings
+func (t TypeTag) MarshalJSON() ([]byte, error) {
+	str := t.String()
+	if str == "" {
+		return nil, errors.New("empty type tag")
+	}
+	return json.Marshal(str)
+}
+
+func (t *TypeTag) UnmarshalJSON(data []byte) error {
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+	tag, err := ParseTypeTag(str)
+	if err != nil {
+		return err
+	}
+	*t = *tag
+	return nil
+}
+
+func shortHex(a AccountAddress) string {
+	str := a.ShortString()
+	if str == "0x" {
+		return "0x0"
+	}
+	return str
+}
+
+type typeTagParser struct {
+	str string
+	pos int
+}
+
+func (p *typeTagParser) typeTag() (*TypeTag, error) {
+	p.skipSpaces()
+	if p.pos == len(p.str) {
+		return nil, fmt.Errorf("invalid type tag %v: unexpected end", p.str)
+	}
+	start := p.pos
+	for p.pos < len(p.str) && isIdentChar(p.str[p.pos]) {
+		p.pos++
+	}
+	token := p.str[start:p.pos]
+	switch token {
+	case "bool":
+		return &TypeTag{Bool: &lib.EmptyEnum{}}, nil
+	case "u8":
+		return &TypeTag{U8: &lib.EmptyEnum{}}, nil
+	case "u16":
+		return &TypeTag{U16: &lib.EmptyEnum{}}, nil
+	case "u32":
+		return &TypeTag{U32: &lib.EmptyEnum{}}, nil
+	case "u64":
+		return &TypeTag{U64: &lib.EmptyEnum{}}, nil
+	case "u128":
+		return &TypeTag{U128: &lib.EmptyEnum{}}, nil
+	case "u256":
+		return &TypeTag{U256: &lib.EmptyEnum{}}, nil
+	case "address":
+		return &TypeTag{Address: &lib.EmptyEnum{}}, nil
+	case "signer":
+		return &TypeTag{Signer: &lib.EmptyEnum{}}, nil
+	case "vector":
+		params, err := p.typeParams()
+		if err != nil {
+			return nil, err
+		}
+		if len(params) != 1 {
+			return nil, fmt.Errorf("invalid type tag %v: vector should have one type param", p.str)
+		}
+		return &TypeTag{Vector: &params[0]}, nil
+	}
+
+	if !strings.HasPrefix(token, "0x") {
+		return nil, fmt.Errorf("invalid type tag %v: unexpected %q", p.str, token)
+	}
+	address, err := NewAccountAddressHex(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type tag %v: unexpected %q", p.str, token)
+	}
+	module, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	tag := &StructTag{Address: *address, Module: module, Name: name}
+	if p.skipSpaces(); p.pos < len(p.str) && p.str[p.pos] == '<' {
+		tag.TypeParams, err = p.typeParams()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &TypeTag{Struct: tag}, nil
+}
+
+// identifier parses `::identifier`
+func (p *typeTagParser) identifier() (Identifier, error) {
+	if !strings.HasPrefix(p.str[p.pos:], "::") {
+		return "", fmt.Errorf("invalid type tag %v: expected ::", p.str)
+	}
+	p.pos += 2
+	start := p.pos
+	for p.pos < len(p.str) && isIdentChar(p.str[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", fmt.Errorf("invalid type tag %v: empty identifier", p.str)
+	}
+	return Identifier(p.str[start:p.pos]), nil
+}
+
+// typeParams parses `<T1, T2, ...>`
+func (p *typeTagParser) typeParams() ([]TypeTag, error) {
+	if p.skipSpaces(); p.pos == len(p.str) || p.str[p.pos] != '<' {
+		return nil, fmt.Errorf("invalid type tag %v: expected <", p.str)
+	}
+	p.pos++
+	var params []TypeTag
+	for {
+		param, err := p.typeTag()
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, *param)
+		p.skipSpaces()
+		if p.pos == len(p.str) {
+			return nil, fmt.Errorf("invalid type tag %v: expected >", p.str)
+		}
+		switch p.str[p.pos] {
+		case ',':
+			p.pos++
+		case '>':
+			p.pos++
+			return params, nil
+		default:
+			return nil, fmt.Errorf("invalid type tag %v: unexpected %q", p.str, p.str[p.pos])
+		}
+	}
+}
+
+func (p *typeTagParser) skipSpaces() {
+	for p.pos < len(p.str) && p.str[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
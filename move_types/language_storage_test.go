@@ -0,0 +1,143 @@
+package move_types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fardream/go-bcs/bcs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypeTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		want    string
+		wantErr bool
+	}{
+		{name: "primitive", str: "u64", want: "u64"},
+		{name: "vector", str: "vector<vector<u8>>", want: "vector<vector<u8>>"},
+		{name: "struct", str: "0x2::sui::SUI", want: "0x2::sui::SUI"},
+		{
+			name: "long address",
+			str:  "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x2::sui::SUI>",
+			want: "0x2::coin::Coin<0x2::sui::SUI>",
+		},
+		{
+			name: "multiple type params",
+			str:  "0x2::dynamic_field::Field<address, vector<0x1::string::String>>",
+			want: "0x2::dynamic_field::Field<address, vector<0x1::string::String>>",
+		},
+		{name: "missing address prefix", str: "2::sui::SUI", wantErr: true},
+		{name: "unclosed", str: "0x2::coin::Coin<0x2::sui::SUI", wantErr: true},
+		{name: "trailing", str: "u64>", wantErr: true},
+		{name: "empty", str: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := ParseTypeTag(tt.str)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, tag.String())
+		})
+	}
+}
+
+func TestTypeTag_JSON(t *testing.T) {
+	tag, err := ParseTypeTag("0x2::coin::Coin<0x2::sui::SUI>")
+	require.NoError(t, err)
+	data, err := json.Marshal([]TypeTag{*tag})
+	require.NoError(t, err)
+	var strs []string
+	err = json.Unmarshal(data, &strs)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0x2::coin::Coin<0x2::sui::SUI>"}, strs)
+
+	var decoded []TypeTag
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, []TypeTag{*tag}, decoded)
+}
+
+func TestTypeTag_BCS(t *testing.T) {
+	tag, err := ParseTypeTag("vector<u8>")
+	require.NoError(t, err)
+	data, err := bcs.Marshal(tag)
+	require.NoError(t, err)
+	require.Equal(t, []byte{6, 1}, data)
+}
+
+func TestTypeTag_BCSBytes(t *testing.T) {
+	address := func(b byte) []byte {
+		addr := make([]byte, SuiAddressLen)
+		addr[len(addr)-1] = b
+		return addr
+	}
+	identifier := func(s string) []byte {
+		return append([]byte{byte(len(s))}, s...)
+	}
+	var sui []byte
+	sui = append(sui, 7)
+	sui = append(sui, address(2)...)
+	sui = append(sui, identifier("sui")...)
+	sui = append(sui, identifier("SUI")...)
+	sui = append(sui, 0)
+	var coin []byte
+	coin = append(coin, 7)
+	coin = append(coin, address(2)...)
+	coin = append(coin, identifier("coin")...)
+	coin = append(coin, identifier("Coin")...)
+	coin = append(coin, 1)
+	coin = append(coin, sui...)
+	var pair []byte
+	pair = append(pair, 7)
+	pair = append(pair, address(0xab)...)
+	pair = append(pair, identifier("pool")...)
+	pair = append(pair, identifier("Pair")...)
+	pair = append(pair, 2)
+	pair = append(pair, append([]byte{6}, coin...)...)
+	pair = append(pair, 2)
+
+	tests := []struct {
+		types []string
+		want  []byte
+	}{
+		{
+			types: []string{"u64"},
+			want:  []byte{2},
+		},
+		{
+			types: []string{"0x2::sui::SUI", "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI"},
+			want:  sui,
+		},
+		{
+			types: []string{
+				"0x2::coin::Coin<0x2::sui::SUI>",
+				"0x02::coin::Coin< 0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI >",
+			},
+			want: coin,
+		},
+		{
+			types: []string{
+				"0xab::pool::Pair<vector<0x2::coin::Coin<0x2::sui::SUI>>, u64>",
+				"0x00ab::pool::Pair< vector< 0x2::coin::Coin<0x02::sui::SUI> >,u64 >",
+			},
+			want: pair,
+		},
+	}
+	for _, tt := range tests {
+		for _, typ := range tt.types {
+			tag, err := ParseTypeTag(typ)
+			require.NoError(t, err, typ)
+			data, err := tag.BCSBytes()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, data, typ)
+		}
+	}
+
+	_, err := TypeTag{}.BCSBytes()
+	require.Error(t, err)
+}
@@ -1,6 +1,9 @@
 package sui_types
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/coming-chat/go-sui/v2/lib"
 	"github.com/coming-chat/go-sui/v2/move_types"
 )
@@ -19,6 +22,21 @@ func NewObjectIdFromHex(str string) (*ObjectID, error) {
 	return move_types.NewAccountAddressHex(str)
 }
 
+// NormalizeObjectId returns the canonical form of the object or package id entered by the users,
+// which is 0x prefixed, zero padded to 64 lowercase hex chars. The surrounding spaces and the 0x prefix are optional,
+// the case is ignored
+func NormalizeObjectId(str string) (string, error) {
+	str = strings.TrimSpace(str)
+	if str == "" || strings.EqualFold(str, "0x") {
+		return "", errors.New("empty object id")
+	}
+	id, err := NewObjectIdFromHex(str)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
 // ObjectRef for BCS, need to keep this order
 type ObjectRef struct {
 	ObjectId ObjectID       `json:"objectId"`
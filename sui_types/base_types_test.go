@@ -0,0 +1,42 @@
+package sui_types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeObjectId(t *testing.T) {
+	const clock = "0x0000000000000000000000000000000000000000000000000000000000000006"
+	tests := []struct {
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{id: "0x6", want: clock},
+		{id: "6", want: clock},
+		{id: " 0X06\n", want: clock},
+		{id: clock, want: clock},
+		{
+			id:   "0x5D4B302506645C37FF133B98C4B50A5AE14841659738D6D733D59D0D217A93BF",
+			want: "0x5d4b302506645c37ff133b98c4b50a5ae14841659738d6d733d59d0d217a93bf",
+		},
+		{id: "", wantErr: true},
+		{id: "0x", wantErr: true},
+		{id: "0xg", wantErr: true},
+		{id: "0x" + clock[2:] + "00", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.id, func(t *testing.T) {
+				got, err := NormalizeObjectId(tt.id)
+				if tt.wantErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
@@ -0,0 +1,7 @@
+package sui_types
+
+// Coin the BCS layout of `0x2::coin::Coin<T>`, which is the UID and the `Balance<T>` of the coin
+type Coin struct {
+	Id      ObjectID
+	Balance uint64
+}
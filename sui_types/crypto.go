@@ -3,6 +3,7 @@ package sui_types
 import (
 	"bytes"
 	"crypto/ed25519"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,17 +15,31 @@ import (
 	"reflect"
 )
 
+// newHasher creates the hasher of all the digests and addresses of the package, which is always blake2b-256
+// in production. The tests can override it to record or count the hashed data, and must restore it afterwards
+var newHasher = func() hash.Hash {
+	digest, err := blake2b.New256([]byte{})
+	if err != nil {
+		panic(err)
+	}
+	return digest
+}
+
+// hash256 returns the digest of data by newHasher
+func hash256(data []byte) (digest [32]byte) {
+	hasher := newHasher()
+	hasher.Write(data)
+	copy(digest[:], hasher.Sum(nil))
+	return digest
+}
+
 type DefaultHash struct {
 	hash.Hash
 }
 
 func NewDefaultHash() DefaultHash {
-	digest, err := blake2b.New256([]byte{})
-	if err != nil {
-		panic(err)
-	}
 	return DefaultHash{
-		digest,
+		newHasher(),
 	}
 }
 
@@ -74,17 +89,11 @@ type Signature struct {
 }
 
 func (s Signature) MarshalJSON() ([]byte, error) {
-	switch {
-	case s.Ed25519SuiSignature != nil:
-		return json.Marshal(s.Ed25519SuiSignature.Signature[:])
-	case s.Secp256k1SuiSignature != nil:
-		return json.Marshal(s.Secp256k1SuiSignature.Signature[:])
-	case s.Secp256r1SuiSignature != nil:
-		return json.Marshal(s.Secp256r1SuiSignature.Signature[:])
-	default:
-		return nil, errors.New("nil signature")
+	data, err := s.Bytes()
+	if err != nil {
+		return nil, err
 	}
-
+	return json.Marshal(data)
 }
 
 func (s *Signature) UnmarshalJSON(data []byte) error {
@@ -93,6 +102,23 @@ func (s *Signature) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
+	return s.setBytes(signature)
+}
+
+// NewSignatureFromBase64 parses the base64 serialized signature(flag || signature || public key)
+func NewSignatureFromBase64(str string) (Signature, error) {
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return Signature{}, err
+	}
+	var signature Signature
+	return signature, signature.setBytes(data)
+}
+
+func (s *Signature) setBytes(signature []byte) error {
+	if len(signature) == 0 {
+		return errors.New("empty signature")
+	}
 	switch signature[0] {
 	case 0:
 		if len(signature) != ed25519.PublicKeySize+ed25519.SignatureSize+1 {
@@ -103,6 +129,20 @@ func (s *Signature) UnmarshalJSON(data []byte) error {
 		s.Ed25519SuiSignature = &Ed25519SuiSignature{
 			Signature: signatureBytes,
 		}
+	case 1:
+		if len(signature) != Secp256k1PublicKeySize+SecpSignatureSize+1 {
+			return errors.New("invalid secp256k1 signature")
+		}
+		s.Secp256k1SuiSignature = &Secp256k1SuiSignature{
+			Signature: signature,
+		}
+	case 2:
+		if len(signature) != Secp256r1PublicKeySize+SecpSignatureSize+1 {
+			return errors.New("invalid secp256r1 signature")
+		}
+		s.Secp256r1SuiSignature = &Secp256r1SuiSignature{
+			Signature: signature,
+		}
 	default:
 		return errors.New("unsupport signature")
 	}
@@ -114,7 +154,10 @@ func NewSignatureSecure[T IntentValue](value IntentMessage[T], secret crypto.Sig
 	if err != nil {
 		return Signature{}, err
 	}
-	hash := blake2b.Sum256(message)
+	if closer, ok := secret.(interface{ Closed() bool }); ok && closer.Closed() {
+		return Signature{}, ErrKeyPairClosed
+	}
+	hash := hash256(message)
 	return secret.Sign(hash[:]), nil
 }
 
@@ -150,9 +193,13 @@ func NewSignatureScheme(flag byte) (SignatureScheme, error) {
 			ED25519: &lib.EmptyEnum{},
 		}, nil
 	case 1:
-		fallthrough
+		return SignatureScheme{
+			Secp256k1: &lib.EmptyEnum{},
+		}, nil
 	case 2:
-		fallthrough
+		return SignatureScheme{
+			Secp256r1: &lib.EmptyEnum{},
+		}, nil
 	case 3:
 		fallthrough
 	case 4:
@@ -162,75 +209,268 @@ func NewSignatureScheme(flag byte) (SignatureScheme, error) {
 	}
 }
 
+const (
+	Secp256k1PublicKeySize = 33 // compressed
+	Secp256r1PublicKeySize = 33 // compressed
+	BLS12381PublicKeySize  = 96
+	SecpSignatureSize      = 64 // r || s
+)
+
+func (s *SignatureScheme) PublicKeySize() (int, error) {
+	switch {
+	case s.ED25519 != nil:
+		return ed25519.PublicKeySize, nil
+	case s.Secp256k1 != nil:
+		return Secp256k1PublicKeySize, nil
+	case s.Secp256r1 != nil:
+		return Secp256r1PublicKeySize, nil
+	case s.BLS12381 != nil:
+		return BLS12381PublicKeySize, nil
+	default:
+		return 0, errors.New("unsupported scheme")
+	}
+}
+
+type PublicKey struct {
+	SignatureScheme
+	Data []byte
+}
+
+// NewPublicKey checks that the length of data matches the scheme
+func NewPublicKey(scheme SignatureScheme, data []byte) (*PublicKey, error) {
+	size, err := scheme.PublicKeySize()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != size {
+		return nil, fmt.Errorf("invalid public key length %d, expected %d", len(data), size)
+	}
+	return &PublicKey{
+		SignatureScheme: scheme,
+		Data:            data,
+	}, nil
+}
+
+// SerializeWithFlag returns flag || public key, which is used in signatures and multisig
+func (p *PublicKey) SerializeWithFlag() []byte {
+	data := make([]byte, 0, len(p.Data)+1)
+	data = append(data, p.Flag())
+	return append(data, p.Data...)
+}
+
+// SuiAddress the address of the public key is blake2b(flag || public key)
+func (p *PublicKey) SuiAddress() SuiAddress {
+	return hash256(p.SerializeWithFlag())
+}
+
+func (p *PublicKey) ToBase64() string {
+	return lib.Base64Data(p.SerializeWithFlag()).String()
+}
+
 type Secp256k1SuiSignature struct {
 	Signature []byte //secp256k1.pubKey + Secp256k1Signature + 1
 }
 
 type Secp256r1SuiSignature struct {
-	Signature []byte //secp256k1.pubKey + Secp256k1Signature + 1
+	Signature []byte //secp256r1.pubKey + Secp256r1Signature + 1
 }
 
 type Ed25519SuiSignature struct {
 	Signature [ed25519.PublicKeySize + ed25519.SignatureSize + 1]byte
 }
 
+// NewSuiKeyPair the seed is the 32 bytes private key for secp256k1 and secp256r1
 func NewSuiKeyPair(scheme SignatureScheme, seed []byte) SuiKeyPair {
 	switch scheme.Flag() {
 	case 0:
 		return SuiKeyPair{
-			Ed25519: crypto.NewEd25519KeyPair(ed25519.NewKeyFromSeed(seed[:])),
+			Ed25519:         crypto.NewEd25519KeyPair(ed25519.NewKeyFromSeed(seed[:])),
+			SignatureScheme: scheme,
+		}
+	case 1:
+		return SuiKeyPair{
+			Secp256k1:       crypto.NewSecp256k1KeyPair(seed),
+			SignatureScheme: scheme,
+		}
+	case 2:
+		return SuiKeyPair{
+			Secp256r1:       crypto.NewSecp256r1KeyPair(seed),
+			SignatureScheme: scheme,
 		}
 	default:
 		return SuiKeyPair{}
 	}
 }
 
+// ErrKeyPairClosed the private key is zeroed by SuiKeyPair.Zero
+var ErrKeyPairClosed = crypto.ErrKeyPairClosed
+
 type SuiKeyPair struct {
-	Ed25519 *crypto.Ed25519KeyPair
-	//Secp256k1 *Secp256k1KeyPair
-	//Secp256r1 *Secp256r1KeyPair
+	Ed25519   *crypto.Ed25519KeyPair
+	Secp256k1 *crypto.Secp256k1KeyPair
+	Secp256r1 *crypto.Secp256r1KeyPair
 	SignatureScheme
 }
 
-func (s *SuiKeyPair) PublicKey() []byte {
-	switch s.Flag() {
-	case 0:
-		return s.Ed25519.PublicKey()
+// keyPair returns the key pair of the scheme, nil if the scheme is unsupported or the key pair of it is missing.
+// A nil pointer is returned as an untyped nil, otherwise the interface is not nil
+func (s *SuiKeyPair) keyPair() crypto.KeyPair {
+	switch {
+	case s.Flag() == 0 && s.Ed25519 != nil:
+		return s.Ed25519
+	case s.Flag() == 1 && s.Secp256k1 != nil:
+		return s.Secp256k1
+	case s.Flag() == 2 && s.Secp256r1 != nil:
+		return s.Secp256r1
 	default:
+		return nil
+	}
+}
+
+func (s *SuiKeyPair) PublicKey() []byte {
+	keyPair := s.keyPair()
+	if keyPair == nil {
 		return []byte{}
 	}
+	return keyPair.PublicKey()
 }
 
 func (s *SuiKeyPair) PrivateKey() []byte {
-	switch s.Flag() {
-	case 0:
-		return s.Ed25519.PrivateKey()
-	default:
+	keyPair := s.keyPair()
+	if keyPair == nil {
 		return []byte{}
 	}
+	return keyPair.PrivateKey()
+}
+
+// Zero overwrites the private key with zeros, the key pair can't be used afterwards
+func (s *SuiKeyPair) Zero() {
+	switch {
+	case s.Ed25519 != nil:
+		s.Ed25519.Zero()
+	case s.Secp256k1 != nil:
+		s.Secp256k1.Zero()
+	case s.Secp256r1 != nil:
+		s.Secp256r1.Zero()
+	}
 }
 
+// Closed reports whether the private key is zeroed by Zero
+func (s *SuiKeyPair) Closed() bool {
+	switch {
+	case s.Ed25519 != nil:
+		return s.Ed25519.Closed()
+	case s.Secp256k1 != nil:
+		return s.Secp256k1.Closed()
+	case s.Secp256r1 != nil:
+		return s.Secp256r1.Closed()
+	default:
+		return false
+	}
+}
+
+// Sign returns an empty Signature if the key pair is missing, and panics if it is closed, see Closed
 func (s *SuiKeyPair) Sign(msg []byte) Signature {
+	if s.keyPair() == nil {
+		return Signature{}
+	}
 	switch s.Flag() {
 	case 0:
 		return Signature{
 			Ed25519SuiSignature: NewEd25519SuiSignature(s.Ed25519, msg),
 		}
+	case 1:
+		return Signature{
+			Secp256k1SuiSignature: &Secp256k1SuiSignature{
+				Signature: serializeSignature(s.SignatureScheme, s.Secp256k1, msg),
+			},
+		}
+	case 2:
+		return Signature{
+			Secp256r1SuiSignature: &Secp256r1SuiSignature{
+				Signature: serializeSignature(s.SignatureScheme, s.Secp256r1, msg),
+			},
+		}
 	default:
 		return Signature{}
 	}
 }
 
-func NewEd25519SuiSignature(keyPair crypto.KeyPair, message []byte) *Ed25519SuiSignature {
-	sig := keyPair.Sign(message)
+// SignTransaction signs the BCS bytes of TransactionData with the default intent,
+// and returns the base64 serialized signature(flag || signature || public key) which can be sent to the node.
+func (s *SuiKeyPair) SignTransaction(txBytes []byte) (string, error) {
+	return s.SignWithIntent(txBytes, DefaultIntent())
+}
 
-	var signatureBytes [ed25519.PublicKeySize + ed25519.SignatureSize + 1]byte
+// SignWithIntent signs the BCS bytes of a value with the intent, e.g. NewIntent of a custom scope,
+// and returns the base64 serialized signature. The bytes are signed as is, without the length prefix
+func (s *SuiKeyPair) SignWithIntent(bcsBytes []byte, intent Intent) (string, error) {
+	if s.keyPair() == nil {
+		return "", errors.New("unsupported scheme")
+	}
+	message := NewIntentMessage(intent, rawBcsBytes(bcsBytes))
+	signature, err := NewSignatureSecure(message, s)
+	if err != nil {
+		return "", err
+	}
+	return signature.ToBase64()
+}
+
+// SignPersonalMessage signs the message with the PersonalMessage intent, the message is BCS encoded as vector<u8>
+// before signing as the wallets do, and returns the base64 serialized signature, see VerifyPersonalMessage
+func (s *SuiKeyPair) SignPersonalMessage(message []byte) (string, error) {
+	if s.keyPair() == nil {
+		return "", errors.New("unsupported scheme")
+	}
+	signature, err := NewSignatureSecure(NewIntentMessage(PersonalMessageIntent(), message), s)
+	if err != nil {
+		return "", err
+	}
+	return signature.ToBase64()
+}
+
+// Bytes returns the serialized signature: flag || signature || public key
+func (s Signature) Bytes() ([]byte, error) {
+	switch {
+	case s.Ed25519SuiSignature != nil:
+		return s.Ed25519SuiSignature.Signature[:], nil
+	case s.Secp256k1SuiSignature != nil:
+		return s.Secp256k1SuiSignature.Signature, nil
+	case s.Secp256r1SuiSignature != nil:
+		return s.Secp256r1SuiSignature.Signature, nil
+	default:
+		return nil, errors.New("nil signature")
+	}
+}
+
+func (s Signature) ToBase64() (string, error) {
+	data, err := s.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return lib.Base64Data(data).String(), nil
+}
+
+func serializeSignature(scheme SignatureScheme, keyPair crypto.KeyPair, message []byte) []byte {
+	sig := keyPair.Sign(message)
 	signatureBuffer := bytes.NewBuffer([]byte{})
-	scheme := SignatureScheme{ED25519: &lib.EmptyEnum{}}
 	signatureBuffer.WriteByte(scheme.Flag())
 	signatureBuffer.Write(sig)
 	signatureBuffer.Write(keyPair.PublicKey())
-	copy(signatureBytes[:], signatureBuffer.Bytes())
+	return signatureBuffer.Bytes()
+}
+
+// rawBcsBytes is already BCS encoded, so it is written without the length prefix
+type rawBcsBytes []byte
+
+func (b rawBcsBytes) MarshalBCS() ([]byte, error) {
+	return b, nil
+}
+
+func NewEd25519SuiSignature(keyPair crypto.KeyPair, message []byte) *Ed25519SuiSignature {
+	var signatureBytes [ed25519.PublicKeySize + ed25519.SignatureSize + 1]byte
+	scheme := SignatureScheme{ED25519: &lib.EmptyEnum{}}
+	copy(signatureBytes[:], serializeSignature(scheme, keyPair, message))
 	return &Ed25519SuiSignature{
 		Signature: signatureBytes,
 	}
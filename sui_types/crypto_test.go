@@ -0,0 +1,317 @@
+package sui_types
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"hash"
+	"math/big"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1_ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/fardream/go-bcs/bcs"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestPublicKey_SerializeWithFlag(t *testing.T) {
+	keyPair := NewSuiKeyPair(SignatureScheme{ED25519: &lib.EmptyEnum{}}, make([]byte, ed25519.SeedSize))
+	pubKey, err := NewPublicKey(keyPair.SignatureScheme, keyPair.PublicKey())
+	require.NoError(t, err)
+
+	serialized := pubKey.SerializeWithFlag()
+	require.Len(t, serialized, ed25519.PublicKeySize+1)
+	require.Equal(t, byte(0), serialized[0])
+	require.Equal(t, keyPair.PublicKey(), serialized[1:])
+	require.Equal(t, base64.StdEncoding.EncodeToString(serialized), pubKey.ToBase64())
+
+	k1Key := make([]byte, Secp256k1PublicKeySize)
+	pubKey, err = NewPublicKey(SignatureScheme{Secp256k1: &lib.EmptyEnum{}}, k1Key)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), pubKey.SerializeWithFlag()[0])
+
+	_, err = NewPublicKey(SignatureScheme{Secp256r1: &lib.EmptyEnum{}}, keyPair.PublicKey())
+	require.Error(t, err)
+	_, err = NewPublicKey(SignatureScheme{MultiSig: &lib.EmptyEnum{}}, keyPair.PublicKey())
+	require.Error(t, err)
+}
+
+func TestSuiKeyPair_SignTransaction(t *testing.T) {
+	txBytes := []byte("transaction data bytes")
+	intentMessage := append([]byte{0, 0, 0}, txBytes...)
+	digest := blake2b.Sum256(intentMessage)
+	hash := sha256.Sum256(digest[:])
+	seed := make([]byte, 32)
+	seed[31] = 1
+
+	for flag := byte(0); flag <= 2; flag++ {
+		scheme, err := NewSignatureScheme(flag)
+		require.NoError(t, err)
+		keyPair := NewSuiKeyPair(scheme, seed)
+
+		serialized, err := keyPair.SignTransaction(txBytes)
+		require.NoError(t, err)
+		signature, err := base64.StdEncoding.DecodeString(serialized)
+		require.NoError(t, err)
+		require.Equal(t, flag, signature[0])
+		pubKey := keyPair.PublicKey()
+		require.Equal(t, pubKey, signature[len(signature)-len(pubKey):])
+		sig := signature[1 : len(signature)-len(pubKey)]
+
+		switch flag {
+		case 0:
+			require.True(t, ed25519.Verify(pubKey, digest[:], sig))
+		case 1:
+			key, err := secp256k1.ParsePubKey(pubKey)
+			require.NoError(t, err)
+			var r, s secp256k1.ModNScalar
+			r.SetByteSlice(sig[:32])
+			s.SetByteSlice(sig[32:])
+			require.True(t, secp256k1_ecdsa.NewSignature(&r, &s).Verify(hash[:], key))
+		case 2:
+			x, y := elliptic.UnmarshalCompressed(elliptic.P256(), pubKey)
+			key := ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+			r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+			require.True(t, ecdsa.Verify(&key, hash[:], r, s))
+		}
+
+		var decoded Signature
+		err = json.Unmarshal([]byte(`"`+serialized+`"`), &decoded)
+		require.NoError(t, err)
+		decodedBytes, err := decoded.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, signature, decodedBytes)
+
+		// the scheme without the key pair
+		_, err = (&SuiKeyPair{SignatureScheme: scheme}).SignTransaction(txBytes)
+		require.Error(t, err)
+		require.Equal(t, Signature{}, (&SuiKeyPair{SignatureScheme: scheme}).Sign(txBytes))
+	}
+}
+
+func TestBatchVerifySignatures(t *testing.T) {
+	txBytes := []byte("transaction data bytes")
+	seed := make([]byte, 32)
+	seed[31] = 1
+
+	var items []SignatureVerifyItem
+	for flag := byte(0); flag <= 2; flag++ {
+		scheme, err := NewSignatureScheme(flag)
+		require.NoError(t, err)
+		keyPair := NewSuiKeyPair(scheme, seed)
+		serialized, err := keyPair.SignTransaction(txBytes)
+		require.NoError(t, err)
+		var signature Signature
+		err = json.Unmarshal([]byte(`"`+serialized+`"`), &signature)
+		require.NoError(t, err)
+		items = append(
+			items,
+			SignatureVerifyItem{TxBytes: txBytes, Signature: signature},
+			SignatureVerifyItem{TxBytes: []byte("other transaction"), Signature: signature},
+		)
+	}
+
+	for _, parallelism := range []int{1, 4} {
+		results := BatchVerifySignatures(items, parallelism)
+		require.Len(t, results, len(items))
+		for i, err := range results {
+			if i%2 == 0 {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidSignature)
+			}
+		}
+	}
+}
+
+func TestPreflightExecute(t *testing.T) {
+	newKeyPair := func(b byte) (SuiKeyPair, SuiAddress) {
+		seed := make([]byte, 32)
+		seed[31] = b
+		keyPair := NewSuiKeyPair(SignatureScheme{ED25519: &lib.EmptyEnum{}}, seed)
+		pubKey, err := NewPublicKey(keyPair.SignatureScheme, keyPair.PublicKey())
+		require.NoError(t, err)
+		return keyPair, pubKey.SuiAddress()
+	}
+	sign := func(keyPair SuiKeyPair, txBytes []byte) Signature {
+		serialized, err := keyPair.SignTransaction(txBytes)
+		require.NoError(t, err)
+		signature, err := NewSignatureFromBase64(serialized)
+		require.NoError(t, err)
+		return signature
+	}
+	senderKey, sender := newKeyPair(1)
+	sponsorKey, sponsor := newKeyPair(2)
+	gas := []*ObjectRef{{ObjectId: ObjectID{1}, Version: 1, Digest: Digest{}}}
+
+	txBytes, err := bcs.Marshal(NewProgrammable(sender, gas, ProgrammableTransaction{}, 1000, 1))
+	require.NoError(t, err)
+	senderSig := sign(senderKey, txBytes)
+	require.NoError(t, PreflightExecute(txBytes, []Signature{senderSig}, sender))
+	require.ErrorIs(t, PreflightExecute(txBytes, []Signature{senderSig}, sponsor), ErrSenderMismatch)
+	require.ErrorIs(t, PreflightExecute(txBytes, nil, sender), ErrSenderMismatch)
+	require.ErrorIs(t, PreflightExecute(txBytes, []Signature{sign(sponsorKey, txBytes)}, sender), ErrSenderMismatch)
+	require.Error(t, PreflightExecute(txBytes, []Signature{senderSig, senderSig}, sender))
+	require.Error(t, PreflightExecute(txBytes[:len(txBytes)-1], []Signature{senderSig}, sender))
+
+	otherBytes, err := bcs.Marshal(NewProgrammable(sender, gas, ProgrammableTransaction{}, 2000, 1))
+	require.NoError(t, err)
+	require.ErrorIs(t, PreflightExecute(txBytes, []Signature{sign(senderKey, otherBytes)}, sender), ErrInvalidSignature)
+
+	sponsoredBytes, err := bcs.Marshal(
+		NewProgrammableAllowSponsor(sender, gas, ProgrammableTransaction{}, 1000, 1, sponsor),
+	)
+	require.NoError(t, err)
+	senderSig, sponsorSig := sign(senderKey, sponsoredBytes), sign(sponsorKey, sponsoredBytes)
+	require.NoError(t, PreflightExecute(sponsoredBytes, []Signature{sponsorSig, senderSig}, sender))
+	require.ErrorIs(t, PreflightExecute(sponsoredBytes, []Signature{senderSig}, sender), ErrSenderMismatch)
+}
+
+func TestSuiKeyPair_SignPersonalMessage(t *testing.T) {
+	message := []byte("sign in with sui")
+	seed := make([]byte, 32)
+	seed[31] = 1
+
+	for flag := byte(0); flag <= 2; flag++ {
+		scheme, err := NewSignatureScheme(flag)
+		require.NoError(t, err)
+		keyPair := NewSuiKeyPair(scheme, seed)
+		pubKey, err := NewPublicKey(scheme, keyPair.PublicKey())
+		require.NoError(t, err)
+
+		signature, err := keyPair.SignPersonalMessage(message)
+		require.NoError(t, err)
+		signer, err := VerifyPersonalMessage(message, signature)
+		require.NoError(t, err)
+		require.Equal(t, pubKey.SuiAddress(), signer)
+		require.Equal(t, SuiAddress(blake2b.Sum256(append([]byte{flag}, keyPair.PublicKey()...))), signer)
+
+		_, err = VerifyPersonalMessage([]byte("sign in with sui!"), signature)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+		// a personal message signature is not a transaction signature
+		sig, err := NewSignatureFromBase64(signature)
+		require.NoError(t, err)
+		require.ErrorIs(t, VerifyTransactionSignature(message, sig), ErrInvalidSignature)
+	}
+
+	// the intent message is 3 || 0 || 0 || uleb128(len) || message
+	keyPair := NewSuiKeyPair(SignatureScheme{ED25519: &lib.EmptyEnum{}}, seed)
+	signature, err := keyPair.SignPersonalMessage(message)
+	require.NoError(t, err)
+	data, err := base64.StdEncoding.DecodeString(signature)
+	require.NoError(t, err)
+	digest := blake2b.Sum256(append([]byte{3, 0, 0, byte(len(message))}, message...))
+	require.True(t, ed25519.Verify(keyPair.PublicKey(), digest[:], data[1:1+ed25519.SignatureSize]))
+}
+
+func TestSuiKeyPair_SignWithIntent(t *testing.T) {
+	for value := IntentScopeTransactionData; value <= IntentScopeHeaderDigest; value++ {
+		scope, err := NewIntentScope(value)
+		require.NoError(t, err)
+		scopeBytes, err := bcs.Marshal(scope)
+		require.NoError(t, err)
+		require.Equal(t, []byte{value}, scopeBytes)
+	}
+	_, err := NewIntentScope(IntentScopeHeaderDigest + 1)
+	require.Error(t, err)
+
+	keyPair := NewSuiKeyPair(SignatureScheme{ED25519: &lib.EmptyEnum{}}, make([]byte, 32))
+	data := []byte("checkpoint summary bytes")
+	scope, err := NewIntentScope(IntentScopeCheckpointSummary)
+	require.NoError(t, err)
+	intent := NewIntent(scope)
+	intentBytes, err := bcs.Marshal(intent)
+	require.NoError(t, err)
+	require.Equal(t, []byte{IntentScopeCheckpointSummary, 0, 0}, intentBytes)
+
+	serialized, err := keyPair.SignWithIntent(data, intent)
+	require.NoError(t, err)
+	signature, err := NewSignatureFromBase64(serialized)
+	require.NoError(t, err)
+	require.NoError(t, VerifyIntentSignature(intent, data, signature))
+	require.ErrorIs(t, VerifyIntentSignature(DefaultIntent(), data, signature), ErrInvalidSignature)
+
+	// the default is still the TransactionData scope
+	serialized, err = keyPair.SignTransaction(data)
+	require.NoError(t, err)
+	signature, err = NewSignatureFromBase64(serialized)
+	require.NoError(t, err)
+	require.NoError(t, VerifyTransactionSignature(data, signature))
+}
+
+func TestVerifyTransactionSender(t *testing.T) {
+	senderKey := NewSuiKeyPair(SignatureScheme{ED25519: &lib.EmptyEnum{}}, make([]byte, 32))
+	pubKey, err := NewPublicKey(senderKey.SignatureScheme, senderKey.PublicKey())
+	require.NoError(t, err)
+	sender := pubKey.SuiAddress()
+
+	// the object inputs are decoded as well
+	object := &ObjectRef{ObjectId: ObjectID{2}, Version: 1, Digest: make([]byte, 32)}
+	gas := []*ObjectRef{{ObjectId: ObjectID{1}, Version: 1, Digest: make([]byte, 32)}}
+	tx, err := NewTransferObjectsWithGasCoins(sender, SuiAddress{3}, []*ObjectRef{object}, gas, 1000, 1)
+	require.NoError(t, err)
+	txBytes, err := tx.TransactionBytes()
+	require.NoError(t, err)
+	serialized, err := senderKey.SignTransaction(txBytes)
+	require.NoError(t, err)
+	signature, err := NewSignatureFromBase64(serialized)
+	require.NoError(t, err)
+
+	signer, err := VerifyTransactionSender(txBytes, signature)
+	require.NoError(t, err)
+	require.Equal(t, sender, signer)
+
+	// the sender is spoofed, the signature is valid for the other sender
+	tx.V1.Sender = SuiAddress{4}
+	tx.V1.GasData.Owner = SuiAddress{4}
+	spoofedBytes, err := tx.TransactionBytes()
+	require.NoError(t, err)
+	_, err = VerifyTransactionSender(spoofedBytes, signature)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	serialized, err = senderKey.SignTransaction(spoofedBytes)
+	require.NoError(t, err)
+	signature, err = NewSignatureFromBase64(serialized)
+	require.NoError(t, err)
+	_, err = VerifyTransactionSender(spoofedBytes, signature)
+	require.ErrorIs(t, err, ErrSenderMismatch)
+}
+
+// recordingHasher records the data hashed by the package
+type recordingHasher struct {
+	hash.Hash
+	hashed *[]byte
+}
+
+func (h recordingHasher) Write(p []byte) (int, error) {
+	*h.hashed = append(*h.hashed, p...)
+	return h.Hash.Write(p)
+}
+
+func TestNewHasher(t *testing.T) {
+	original := newHasher
+	defer func() { newHasher = original }()
+	var hashed [][]byte
+	newHasher = func() hash.Hash {
+		hashed = append(hashed, nil)
+		return recordingHasher{Hash: original(), hashed: &hashed[len(hashed)-1]}
+	}
+
+	txBytes := []byte{1, 2, 3}
+	digest := ComputeTransactionDigest(txBytes)
+	require.Len(t, hashed, 1)
+	require.Equal(t, append([]byte("TransactionData::"), txBytes...), hashed[0])
+	expected := blake2b.Sum256(hashed[0])
+	require.Equal(t, expected[:], []byte(digest))
+
+	pubKey, err := NewPublicKey(SignatureScheme{ED25519: &lib.EmptyEnum{}}, make([]byte, 32))
+	require.NoError(t, err)
+	address := pubKey.SuiAddress()
+	require.Len(t, hashed, 2)
+	require.Equal(t, pubKey.SerializeWithFlag(), hashed[1])
+	require.Equal(t, SuiAddress(blake2b.Sum256(hashed[1])), address)
+}
@@ -2,6 +2,9 @@ package sui_types
 
 import "github.com/coming-chat/go-sui/v2/lib"
 
+// DigestLength the length of the digests, which are blake2b-256 hashes
+const DigestLength = 32
+
 type Digest = lib.Base58
 
 type ObjectDigest = Digest
@@ -18,6 +21,8 @@ type CertificateDigest = Digest
 
 type CheckpointContentsDigest = Digest
 
+type ConsensusCommitDigest = Digest
+
 func NewDigest(str string) (*Digest, error) {
 	return lib.NewBase58(str)
 }
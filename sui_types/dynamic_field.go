@@ -1,6 +1,12 @@
 package sui_types
 
-import "github.com/coming-chat/go-sui/v2/lib"
+import (
+	"encoding/binary"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/fardream/go-bcs/bcs"
+)
 
 type DynamicFieldType struct {
 	DynamicField  *lib.EmptyEnum `json:"DynamicField"`
@@ -19,3 +25,29 @@ type DynamicFieldName struct {
 	Type  string `json:"type"`
 	Value any    `json:"value"`
 }
+
+// ChildObjectIdScope is the HashingIntentScope which separates the derived child object ids from other hashes
+const ChildObjectIdScope = 0xf0
+
+// DeriveDynamicFieldObjectId computes the object id of the dynamic field `Field<K, V>` of parent offline, which is
+// blake2b256(0xf0 || parent || len(keyBytes) as u64 little endian || keyBytes || bcs(keyType)).
+// keyBytes is the BCS bytes of the key, i.e. the `bcsName` of DynamicFieldInfo.
+// NOTE: the key type of a dynamic object field is wrapped as `0x2::dynamic_object_field::Wrapper<K>`
+func DeriveDynamicFieldObjectId(parent ObjectID, keyType move_types.TypeTag, keyBytes []byte) (ObjectID, error) {
+	typeBytes, err := bcs.Marshal(keyType)
+	if err != nil {
+		return ObjectID{}, err
+	}
+	keyLen := make([]byte, 8)
+	binary.LittleEndian.PutUint64(keyLen, uint64(len(keyBytes)))
+
+	digest := NewDefaultHash()
+	digest.Write([]byte{ChildObjectIdScope})
+	digest.Write(parent[:])
+	digest.Write(keyLen)
+	digest.Write(keyBytes)
+	digest.Write(typeBytes)
+	var id ObjectID
+	copy(id[:], digest.Sum(nil))
+	return id, nil
+}
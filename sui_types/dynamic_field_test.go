@@ -0,0 +1,28 @@
+package sui_types
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveDynamicFieldObjectId(t *testing.T) {
+	// the inner state of 0x5 SuiSystemState is the dynamic field keyed by the u64 version, at version 2 it is
+	// 0x5b890eaf2abcfa2ab90b77b8e6f3d5d8609586c3e583baf3dccd5af17edf48d1 on mainnet and testnet,
+	// the entries of a Table<u64, V> are derived the same way
+	parent, err := NewObjectIdFromHex("0x5")
+	require.NoError(t, err)
+	key := make([]byte, 8)
+	binary.LittleEndian.PutUint64(key, 2)
+
+	id, err := DeriveDynamicFieldObjectId(*parent, move_types.TypeTag{U64: &lib.EmptyEnum{}}, key)
+	require.NoError(t, err)
+	require.Equal(t, "0x5b890eaf2abcfa2ab90b77b8e6f3d5d8609586c3e583baf3dccd5af17edf48d1", id.String())
+
+	other, err := DeriveDynamicFieldObjectId(*parent, move_types.TypeTag{Address: &lib.EmptyEnum{}}, key)
+	require.NoError(t, err)
+	require.NotEqual(t, id, other)
+}
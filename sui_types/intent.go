@@ -1,9 +1,22 @@
 package sui_types
 
 import (
+	"errors"
+
 	"github.com/coming-chat/go-sui/v2/lib"
 )
 
+// The BCS values of IntentScope, which is the first byte of the intent message
+const (
+	IntentScopeTransactionData byte = iota
+	IntentScopeTransactionEffects
+	IntentScopeCheckpointSummary
+	IntentScopePersonalMessage
+	IntentScopeSenderSignedTransaction
+	IntentScopeProofOfPossession
+	IntentScopeHeaderDigest
+)
+
 type IntentScope struct {
 	TransactionData         *lib.EmptyEnum // Used for a user signature on a transaction data.
 	TransactionEffects      *lib.EmptyEnum // Used for an authority signature on transaction effects.
@@ -17,6 +30,27 @@ type IntentScope struct {
 func (i IntentScope) IsBcsEnum() {
 }
 
+func NewIntentScope(scope byte) (IntentScope, error) {
+	switch scope {
+	case IntentScopeTransactionData:
+		return IntentScope{TransactionData: &lib.EmptyEnum{}}, nil
+	case IntentScopeTransactionEffects:
+		return IntentScope{TransactionEffects: &lib.EmptyEnum{}}, nil
+	case IntentScopeCheckpointSummary:
+		return IntentScope{CheckpointSummary: &lib.EmptyEnum{}}, nil
+	case IntentScopePersonalMessage:
+		return IntentScope{PersonalMessage: &lib.EmptyEnum{}}, nil
+	case IntentScopeSenderSignedTransaction:
+		return IntentScope{SenderSignedTransaction: &lib.EmptyEnum{}}, nil
+	case IntentScopeProofOfPossession:
+		return IntentScope{ProofOfPossession: &lib.EmptyEnum{}}, nil
+	case IntentScopeHeaderDigest:
+		return IntentScope{HeaderDigest: &lib.EmptyEnum{}}, nil
+	default:
+		return IntentScope{}, errors.New("unsupported intent scope")
+	}
+}
+
 type IntentVersion struct {
 	V0 *lib.EmptyEnum
 }
@@ -38,11 +72,10 @@ type Intent struct {
 	AppId   AppId
 }
 
-func DefaultIntent() Intent {
+// NewIntent the intent of the scope with the current version and the Sui app id
+func NewIntent(scope IntentScope) Intent {
 	return Intent{
-		Scope: IntentScope{
-			TransactionData: &lib.EmptyEnum{},
-		},
+		Scope: scope,
 		Version: IntentVersion{
 			V0: &lib.EmptyEnum{},
 		},
@@ -52,6 +85,15 @@ func DefaultIntent() Intent {
 	}
 }
 
+func DefaultIntent() Intent {
+	return NewIntent(IntentScope{TransactionData: &lib.EmptyEnum{}})
+}
+
+// PersonalMessageIntent the intent of the personal messages signed by wallets, e.g. sign-in with Sui
+func PersonalMessageIntent() Intent {
+	return NewIntent(IntentScope{PersonalMessage: &lib.EmptyEnum{}})
+}
+
 type IntentValue interface {
 	TransactionData | ~[]byte
 }
@@ -0,0 +1,116 @@
+package sui_types
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// ledgerStatusOK is the APDU status word appended to the responses of the Ledger device
+var ledgerStatusOK = []byte{0x90, 0x00}
+
+// AssembleLedgerSignature wraps the raw signature and the public key returned by the Ledger device
+// into the base64 serialized signature(flag || signature || public key) which can be sent to the node.
+//
+// The Sui app of Ledger returns the 64 bytes ed25519 signature, which may still end with the APDU status word 0x9000
+// when it is read from the raw response, and the public key may be prefixed with its length or the scheme flag,
+// these are trimmed before assembling.
+func AssembleLedgerSignature(scheme byte, rawSig, pubkey []byte) (string, error) {
+	signature, err := assembleLedgerSignature(scheme, rawSig, pubkey)
+	if err != nil {
+		return "", err
+	}
+	return signature.ToBase64()
+}
+
+func assembleLedgerSignature(scheme byte, rawSig, pubkey []byte) (Signature, error) {
+	signatureScheme, err := NewSignatureScheme(scheme)
+	if err != nil {
+		return Signature{}, err
+	}
+	pubKeySize, err := signatureScheme.PublicKeySize()
+	if err != nil {
+		return Signature{}, err
+	}
+	sigSize := SecpSignatureSize
+	if signatureScheme.ED25519 != nil {
+		sigSize = ed25519.SignatureSize
+	}
+
+	if len(rawSig) == sigSize+len(ledgerStatusOK) &&
+		rawSig[sigSize] == ledgerStatusOK[0] && rawSig[sigSize+1] == ledgerStatusOK[1] {
+		rawSig = rawSig[:sigSize]
+	}
+	if len(rawSig) != sigSize {
+		return Signature{}, fmt.Errorf("invalid signature length %d, expected %d", len(rawSig), sigSize)
+	}
+	if len(pubkey) == pubKeySize+1 && (pubkey[0] == byte(pubKeySize) || pubkey[0] == scheme) {
+		pubkey = pubkey[1:]
+	}
+	if len(pubkey) != pubKeySize {
+		return Signature{}, fmt.Errorf("invalid public key length %d, expected %d", len(pubkey), pubKeySize)
+	}
+
+	data := make([]byte, 0, 1+sigSize+pubKeySize)
+	data = append(data, scheme)
+	data = append(data, rawSig...)
+	data = append(data, pubkey...)
+	var signature Signature
+	return signature, signature.setBytes(data)
+}
+
+// LedgerSignFunc signs the BCS bytes of the intent message on the device, which are hashed by the Sui app of Ledger,
+// and returns the raw signature
+type LedgerSignFunc func(intentMessage []byte) ([]byte, error)
+
+// LedgerSigner signs transactions with a hardware wallet, it can be used as the signer of client.SignAndExecuteTransaction
+type LedgerSigner struct {
+	Scheme    byte
+	PublicKey []byte
+	sign      LedgerSignFunc
+}
+
+// NewLedgerSigner the public key is the one of the derivation path used by sign, which is usually read from the device
+func NewLedgerSigner(scheme byte, pubkey []byte, sign LedgerSignFunc) (*LedgerSigner, error) {
+	if sign == nil {
+		return nil, errors.New("sign function is nil")
+	}
+	return &LedgerSigner{Scheme: scheme, PublicKey: pubkey, sign: sign}, nil
+}
+
+// Address the address of the public key
+func (l *LedgerSigner) Address() (SuiAddress, error) {
+	scheme, err := NewSignatureScheme(l.Scheme)
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	pubKey, err := NewPublicKey(scheme, l.PublicKey)
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	return pubKey.SuiAddress(), nil
+}
+
+// SignSecureWithoutEncode signs the BCS bytes of the transaction data with the intent on the device,
+// the assembled signature is verified with the public key, so a signature of another key is rejected
+func (l *LedgerSigner) SignSecureWithoutEncode(txnBytes []byte, intent Intent) (Signature, error) {
+	message, err := bcs.Marshal(NewIntentMessage(intent, rawBcsBytes(txnBytes)))
+	if err != nil {
+		return Signature{}, err
+	}
+	rawSig, err := l.sign(message)
+	if err != nil {
+		return Signature{}, fmt.Errorf("ledger failed to sign: %w", err)
+	}
+	signature, err := assembleLedgerSignature(l.Scheme, rawSig, l.PublicKey)
+	if err != nil {
+		return Signature{}, err
+	}
+	err = verifyIntentSignature(intent, txnBytes, signature)
+	if err != nil {
+		return Signature{}, err
+	}
+	return signature, nil
+}
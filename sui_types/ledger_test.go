@@ -0,0 +1,103 @@
+package sui_types
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestAssembleLedgerSignature(t *testing.T) {
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	pubKey := []byte(privateKey.Public().(ed25519.PublicKey))
+	rawSig := ed25519.Sign(privateKey, []byte("message"))
+
+	expected := append(append([]byte{0}, rawSig...), pubKey...)
+	for _, tt := range []struct {
+		name   string
+		rawSig []byte
+		pubKey []byte
+	}{
+		{name: "raw", rawSig: rawSig, pubKey: pubKey},
+		{name: "status word", rawSig: append(append([]byte{}, rawSig...), 0x90, 0x00), pubKey: pubKey},
+		{name: "length prefixed public key", rawSig: rawSig, pubKey: append([]byte{32}, pubKey...)},
+		{name: "flag prefixed public key", rawSig: rawSig, pubKey: append([]byte{0}, pubKey...)},
+	} {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				signature, err := AssembleLedgerSignature(0, tt.rawSig, tt.pubKey)
+				require.NoError(t, err)
+				sig, err := NewSignatureFromBase64(signature)
+				require.NoError(t, err)
+				data, err := sig.Bytes()
+				require.NoError(t, err)
+				require.Equal(t, expected, data)
+			},
+		)
+	}
+
+	_, err := AssembleLedgerSignature(0, rawSig[:63], pubKey)
+	require.Error(t, err)
+	_, err = AssembleLedgerSignature(0, rawSig, pubKey[:31])
+	require.Error(t, err)
+	_, err = AssembleLedgerSignature(3, rawSig, pubKey)
+	require.Error(t, err)
+}
+
+func TestLedgerSigner(t *testing.T) {
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	pubKey := []byte(privateKey.Public().(ed25519.PublicKey))
+	device := func(intentMessage []byte) ([]byte, error) {
+		digest := blake2b.Sum256(intentMessage)
+		return append(ed25519.Sign(privateKey, digest[:]), 0x90, 0x00), nil
+	}
+	signer, err := NewLedgerSigner(0, pubKey, device)
+	require.NoError(t, err)
+
+	txBytes := []byte{0, 0, 2, 0, 8}
+	signature, err := signer.SignSecureWithoutEncode(txBytes, DefaultIntent())
+	require.NoError(t, err)
+	require.NoError(t, VerifyTransactionSignature(txBytes, signature))
+
+	keyPair := NewSuiKeyPair(SignatureScheme{ED25519: &lib.EmptyEnum{}}, make([]byte, ed25519.SeedSize))
+	expected, err := keyPair.SignTransaction(txBytes)
+	require.NoError(t, err)
+	actual, err := signature.ToBase64()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	address, err := signer.Address()
+	require.NoError(t, err)
+	pub, err := NewPublicKey(keyPair.SignatureScheme, keyPair.PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, pub.SuiAddress(), address)
+
+	otherSeed := make([]byte, ed25519.SeedSize)
+	otherSeed[0] = 1
+	otherKey := ed25519.NewKeyFromSeed(otherSeed)
+	signer, err = NewLedgerSigner(
+		0, pubKey, func(intentMessage []byte) ([]byte, error) {
+			digest := blake2b.Sum256(intentMessage)
+			return ed25519.Sign(otherKey, digest[:]), nil
+		},
+	)
+	require.NoError(t, err)
+	_, err = signer.SignSecureWithoutEncode(txBytes, DefaultIntent())
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	deviceErr := errors.New("rejected on the device")
+	signer, err = NewLedgerSigner(
+		0, pubKey, func(intentMessage []byte) ([]byte, error) {
+			return nil, deviceErr
+		},
+	)
+	require.NoError(t, err)
+	_, err = signer.SignSecureWithoutEncode(txBytes, DefaultIntent())
+	require.ErrorIs(t, err, deviceErr)
+
+	_, err = NewLedgerSigner(0, pubKey, nil)
+	require.Error(t, err)
+}
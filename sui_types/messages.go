@@ -1,9 +1,14 @@
 package sui_types
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
 	"github.com/coming-chat/go-sui/v2/lib"
 	"github.com/coming-chat/go-sui/v2/move_types"
 	"github.com/coming-chat/go-sui/v2/sui_protocol"
+	"github.com/fardream/go-bcs/bcs"
 )
 
 type TransactionData struct {
@@ -14,6 +19,76 @@ func (t TransactionData) IsBcsEnum() {
 
 }
 
+func (t TransactionData) Kind() TransactionKindType {
+	if t.V1 == nil {
+		return TransactionKindUnknown
+	}
+	return t.V1.Kind.Type()
+}
+
+// TransactionBytes returns the BCS bytes of the complete TransactionData, which are the bytes to sign, and are
+// accepted by dryRunTransactionBlock without a signature. The unset expiration is encoded as None,
+// which is the default of the builder, the gas data is encoded as is, see Client.DryRunTransactionData to fill it.
+func (t TransactionData) TransactionBytes() ([]byte, error) {
+	if t.V1 == nil {
+		return nil, errors.New("nil transaction data")
+	}
+	txV1 := *t.V1
+	if txV1.Kind.Type() == TransactionKindUnknown {
+		return nil, errors.New("transaction kind is not set")
+	}
+	if txV1.Expiration.None == nil && txV1.Expiration.Epoch == nil {
+		txV1.Expiration = TransactionExpiration{None: &lib.EmptyEnum{}}
+	}
+	return bcs.Marshal(TransactionData{V1: &txV1})
+}
+
+// CanonicalTransactionJSON returns a deterministic JSON of the transaction for the audit logs, the same transaction
+// always gives the same bytes:
+//   - the transaction is normalized by a round trip of TransactionBytes, so it is exactly the transaction to sign
+//   - the unset variants of the enums are omitted instead of null, e.g. `{"Input":1}` for Argument
+//   - the keys of the objects are sorted, the addresses are the full length lower case hex
+func CanonicalTransactionJSON(tx TransactionData) ([]byte, error) {
+	txBytes, err := tx.TransactionBytes()
+	if err != nil {
+		return nil, err
+	}
+	var normalized TransactionData
+	if _, err := lib.UnmarshalBcs(txBytes, &normalized); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	// json.Marshal sorts the keys of the maps
+	return json.Marshal(omitNullFields(value))
+}
+
+func omitNullFields(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if field == nil {
+				delete(v, key)
+			} else {
+				v[key] = omitNullFields(field)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = omitNullFields(item)
+		}
+	}
+	return value
+}
+
 type TransactionDataV1 struct {
 	Kind       TransactionKind
 	Sender     SuiAddress
@@ -29,6 +104,14 @@ type TransactionExpiration struct {
 func (t TransactionExpiration) IsBcsEnum() {
 }
 
+// EpochBound returns the last epoch the transaction can be executed in, false if the transaction never expires
+func (t TransactionExpiration) EpochBound() (EpochId, bool) {
+	if t.Epoch == nil {
+		return 0, false
+	}
+	return *t.Epoch, true
+}
+
 type GasData struct {
 	Payment []*ObjectRef
 	Owner   SuiAddress
@@ -37,21 +120,170 @@ type GasData struct {
 }
 
 type TransactionKind struct {
-	ProgrammableTransaction *ProgrammableTransaction
-	ChangeEpoch             *ChangeEpoch
-	Genesis                 *GenesisTransaction
-	ConsensusCommitPrologue *ConsensusCommitPrologue
+	ProgrammableTransaction   *ProgrammableTransaction
+	ChangeEpoch               *ChangeEpoch
+	Genesis                   *GenesisTransaction
+	ConsensusCommitPrologue   *ConsensusCommitPrologue
+	AuthenticatorStateUpdate  *AuthenticatorStateUpdate
+	EndOfEpochTransaction     *[]EndOfEpochTransactionKind
+	RandomnessStateUpdate     *RandomnessStateUpdate
+	ConsensusCommitPrologueV2 *ConsensusCommitPrologueV2
+	ConsensusCommitPrologueV3 *ConsensusCommitPrologueV3
 }
 
 func (t TransactionKind) IsBcsEnum() {
 }
 
+type TransactionKindType string
+
+const (
+	TransactionKindProgrammableTransaction   TransactionKindType = "ProgrammableTransaction"
+	TransactionKindChangeEpoch               TransactionKindType = "ChangeEpoch"
+	TransactionKindGenesis                   TransactionKindType = "Genesis"
+	TransactionKindConsensusCommitPrologue   TransactionKindType = "ConsensusCommitPrologue"
+	TransactionKindAuthenticatorStateUpdate  TransactionKindType = "AuthenticatorStateUpdate"
+	TransactionKindEndOfEpochTransaction     TransactionKindType = "EndOfEpochTransaction"
+	TransactionKindRandomnessStateUpdate     TransactionKindType = "RandomnessStateUpdate"
+	TransactionKindConsensusCommitPrologueV2 TransactionKindType = "ConsensusCommitPrologueV2"
+	TransactionKindConsensusCommitPrologueV3 TransactionKindType = "ConsensusCommitPrologueV3"
+	TransactionKindUnknown                   TransactionKindType = ""
+)
+
+// Type returns the variant of the transaction kind, TransactionKindUnknown if no variant is set
+func (t TransactionKind) Type() TransactionKindType {
+	switch {
+	case t.ProgrammableTransaction != nil:
+		return TransactionKindProgrammableTransaction
+	case t.ChangeEpoch != nil:
+		return TransactionKindChangeEpoch
+	case t.Genesis != nil:
+		return TransactionKindGenesis
+	case t.ConsensusCommitPrologue != nil:
+		return TransactionKindConsensusCommitPrologue
+	case t.AuthenticatorStateUpdate != nil:
+		return TransactionKindAuthenticatorStateUpdate
+	case t.EndOfEpochTransaction != nil:
+		return TransactionKindEndOfEpochTransaction
+	case t.RandomnessStateUpdate != nil:
+		return TransactionKindRandomnessStateUpdate
+	case t.ConsensusCommitPrologueV2 != nil:
+		return TransactionKindConsensusCommitPrologueV2
+	case t.ConsensusCommitPrologueV3 != nil:
+		return TransactionKindConsensusCommitPrologueV3
+	default:
+		return TransactionKindUnknown
+	}
+}
+
+// IsSystemTx returns true for the transactions created by validators rather than users
+func (t TransactionKind) IsSystemTx() bool {
+	kind := t.Type()
+	return kind != TransactionKindProgrammableTransaction && kind != TransactionKindUnknown
+}
+
+// CommitPrologue returns the epoch, round and commit timestamp shared by all versions of ConsensusCommitPrologue,
+// nil if the transaction is not a consensus commit prologue
+func (t TransactionKind) CommitPrologue() *ConsensusCommitPrologue {
+	switch {
+	case t.ConsensusCommitPrologue != nil:
+		return t.ConsensusCommitPrologue
+	case t.ConsensusCommitPrologueV2 != nil:
+		return &ConsensusCommitPrologue{
+			Epoch:             t.ConsensusCommitPrologueV2.Epoch,
+			Round:             t.ConsensusCommitPrologueV2.Round,
+			CommitTimestampMs: t.ConsensusCommitPrologueV2.CommitTimestampMs,
+		}
+	case t.ConsensusCommitPrologueV3 != nil:
+		return &ConsensusCommitPrologue{
+			Epoch:             t.ConsensusCommitPrologueV3.Epoch,
+			Round:             t.ConsensusCommitPrologueV3.Round,
+			CommitTimestampMs: t.ConsensusCommitPrologueV3.CommitTimestampMs,
+		}
+	default:
+		return nil
+	}
+}
+
 type ConsensusCommitPrologue struct {
 	Epoch             uint64
 	Round             uint64
 	CommitTimestampMs CheckpointTimestamp
 }
 
+type ConsensusCommitPrologueV2 struct {
+	Epoch                 uint64
+	Round                 uint64
+	CommitTimestampMs     CheckpointTimestamp
+	ConsensusCommitDigest ConsensusCommitDigest
+}
+
+type ConsensusCommitPrologueV3 struct {
+	Epoch uint64
+	Round uint64
+	// Index of the sub dag within the consensus commit, only set when the commit contains multiple sub dags
+	SubDagIndex                           *uint64 `bcs:"optional"`
+	CommitTimestampMs                     CheckpointTimestamp
+	ConsensusCommitDigest                 ConsensusCommitDigest
+	ConsensusDeterminedVersionAssignments ConsensusDeterminedVersionAssignments
+}
+
+type ConsensusDeterminedVersionAssignments struct {
+	CancelledTransactions *[]struct {
+		Digest             TransactionDigest
+		VersionAssignments []struct {
+			ObjectId       ObjectID
+			SequenceNumber SequenceNumber
+		}
+	}
+}
+
+func (c ConsensusDeterminedVersionAssignments) IsBcsEnum() {
+}
+
+type AuthenticatorStateUpdate struct {
+	Epoch                                uint64
+	Round                                uint64
+	NewActiveJwks                        []ActiveJwk
+	AuthenticatorObjInitialSharedVersion SequenceNumber
+}
+
+type ActiveJwk struct {
+	JwkId struct {
+		Iss string
+		Kid string
+	}
+	Jwk struct {
+		Kty string
+		E   string
+		N   string
+		Alg string
+	}
+	Epoch uint64
+}
+
+type RandomnessStateUpdate struct {
+	Epoch                             uint64
+	RandomnessRound                   uint64
+	RandomBytes                       []uint8
+	RandomnessObjInitialSharedVersion SequenceNumber
+}
+
+type EndOfEpochTransactionKind struct {
+	ChangeEpoch              *ChangeEpoch
+	AuthenticatorStateCreate *lib.EmptyEnum
+	AuthenticatorStateExpire *struct {
+		MinEpoch                             uint64
+		AuthenticatorObjInitialSharedVersion SequenceNumber
+	}
+	RandomnessStateCreate *lib.EmptyEnum
+	DenyListStateCreate   *lib.EmptyEnum
+	BridgeStateCreate     *CheckpointDigest
+	BridgeCommitteeInit   *SequenceNumber
+}
+
+func (e EndOfEpochTransactionKind) IsBcsEnum() {
+}
+
 type ProgrammableTransaction struct {
 	Inputs   []CallArg
 	Commands []Command
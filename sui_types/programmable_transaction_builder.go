@@ -7,6 +7,7 @@ import (
 	"github.com/coming-chat/go-sui/v2/move_types"
 	"github.com/fardream/go-bcs/bcs"
 	"github.com/mitchellh/hashstructure/v2"
+	"reflect"
 	"strconv"
 )
 
@@ -101,6 +102,22 @@ func (p *ProgrammableTransactionBuilder) Pure(value any) (Argument, error) {
 	return p.pureBytes(pureData, false), nil
 }
 
+// PureByteVectors adds a `vector<vector<u8>>` pure input, see EncodePureByteVectors
+func (p *ProgrammableTransactionBuilder) PureByteVectors(vectors [][]byte) Argument {
+	return p.pureBytes(EncodePureByteVectors(vectors), false)
+}
+
+// EncodePureByteVectors returns the BCS bytes of `vector<vector<u8>>`, which is the uleb128 length of the outer
+// vector followed by each inner vector with its uleb128 length, e.g. the compiled modules
+func EncodePureByteVectors(vectors [][]byte) []byte {
+	data := bcs.ULEB128Encode(len(vectors))
+	for _, vector := range vectors {
+		data = append(data, bcs.ULEB128Encode(len(vector))...)
+		data = append(data, vector...)
+	}
+	return data
+}
+
 func (p *ProgrammableTransactionBuilder) Obj(objArg ObjectArg) (Argument, error) {
 	id := objArg.id()
 	var oj ObjectArg
@@ -116,7 +133,8 @@ func (p *ProgrammableTransactionBuilder) Obj(objArg ObjectArg) (Argument, error)
 		}
 
 		switch {
-		case oldObjArg.SharedObject.InitialSharedVersion == objArg.SharedObject.InitialSharedVersion:
+		case oldObjArg.SharedObject != nil && objArg.SharedObject != nil &&
+			oldObjArg.SharedObject.InitialSharedVersion == objArg.SharedObject.InitialSharedVersion:
 			if oldObjArg.id() != objArg.id() {
 				return Argument{}, errors.New("invariant violation! object has id does not match call arg")
 			}
@@ -131,6 +149,9 @@ func (p *ProgrammableTransactionBuilder) Obj(objArg ObjectArg) (Argument, error)
 					Mutable:              oldObjArg.SharedObject.Mutable || objArg.SharedObject.Mutable,
 				},
 			}
+		case oldObjArg.ImmOrOwnedObject != nil && objArg.ImmOrOwnedObject != nil &&
+			reflect.DeepEqual(*oldObjArg.ImmOrOwnedObject, *objArg.ImmOrOwnedObject):
+			oj = oldObjArg
 		default:
 			if oldObjArg != objArg {
 				return Argument{}, fmt.Errorf(
@@ -155,6 +176,25 @@ func (p *ProgrammableTransactionBuilder) Obj(objArg ObjectArg) (Argument, error)
 	}, nil
 }
 
+// SharedObj adds the shared object as an input, initialSharedVersion can be queried by
+// Client.GetSharedObjectInitialVersion. Only request mutable if a command takes the object by `&mut`,
+// read-only shared inputs are not sequenced against other writers
+func (p *ProgrammableTransactionBuilder) SharedObj(
+	id ObjectID,
+	initialSharedVersion SequenceNumber,
+	mutable bool,
+) (Argument, error) {
+	return p.Obj(
+		ObjectArg{
+			SharedObject: &struct {
+				Id                   ObjectID
+				InitialSharedVersion SequenceNumber
+				Mutable              bool
+			}{Id: id, InitialSharedVersion: initialSharedVersion, Mutable: mutable},
+		},
+	)
+}
+
 func (p *ProgrammableTransactionBuilder) Input(callArg CallArg) (Argument, error) {
 	switch {
 	case callArg.Pure != nil:
@@ -194,6 +234,49 @@ func (p *ProgrammableTransactionBuilder) Command(command Command) Argument {
 	}
 }
 
+// Result references all the results of the command at commandIndex, e.g. the vector of MakeMoveVec,
+// it is the Argument returned by Command
+func (p *ProgrammableTransactionBuilder) Result(commandIndex uint16) (Argument, error) {
+	if int(commandIndex) >= len(p.Commands) {
+		return Argument{}, fmt.Errorf("command index %d out of range, there are %d commands", commandIndex, len(p.Commands))
+	}
+	return Argument{Result: &commandIndex}, nil
+}
+
+// NestedResult references the result at resultIndex of the command at commandIndex, e.g. the coins of SplitCoins.
+// The resultIndex is checked against the number of results of the command if it is known by the builder,
+// the results of a MoveCall are checked by the node since they depend on the function
+func (p *ProgrammableTransactionBuilder) NestedResult(commandIndex, resultIndex uint16) (Argument, error) {
+	if int(commandIndex) >= len(p.Commands) {
+		return Argument{}, fmt.Errorf("command index %d out of range, there are %d commands", commandIndex, len(p.Commands))
+	}
+	if count, ok := commandResultCount(p.Commands[commandIndex]); ok && int(resultIndex) >= count {
+		return Argument{}, fmt.Errorf(
+			"result index %d out of range, command %d has %d results", resultIndex, commandIndex, count,
+		)
+	}
+	return Argument{
+		NestedResult: &struct {
+			Result1 uint16
+			Result2 uint16
+		}{Result1: commandIndex, Result2: resultIndex},
+	}, nil
+}
+
+// commandResultCount returns the number of the results of the command, false if it is unknown
+func commandResultCount(command Command) (int, bool) {
+	switch {
+	case command.SplitCoins != nil:
+		return len(command.SplitCoins.Arguments), true
+	case command.TransferObjects != nil, command.MergeCoins != nil:
+		return 0, true
+	case command.Publish != nil, command.MakeMoveVec != nil, command.Upgrade != nil:
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
 func (p *ProgrammableTransactionBuilder) TransferObject(
 	recipient SuiAddress,
 	objectRefs []*ObjectRef,
@@ -225,6 +308,151 @@ func (p *ProgrammableTransactionBuilder) TransferObject(
 	return nil
 }
 
+// TransferObjects transfers the objects to recipient, the objects can be inputs or results of prior commands,
+// e.g. the NestedResult of SplitCoins
+func (p *ProgrammableTransactionBuilder) TransferObjects(objects []Argument, recipient SuiAddress) error {
+	if len(objects) == 0 {
+		return errors.New("objects is empty")
+	}
+	recArg, err := p.Pure(recipient)
+	if err != nil {
+		return err
+	}
+	p.Command(
+		Command{
+			TransferObjects: &struct {
+				Arguments []Argument
+				Argument  Argument
+			}{Arguments: objects, Argument: recArg},
+		},
+	)
+	return nil
+}
+
+// BatchTransferObjects transfers objects[i] to recipients[i], the objects are paired with the recipients by index
+// instead of being distributed among them, so both must have the same length.
+// The objects of the same recipient are transferred by one command
+func (p *ProgrammableTransactionBuilder) BatchTransferObjects(objects []Argument, recipients []SuiAddress) error {
+	if len(objects) != len(recipients) {
+		return fmt.Errorf(
+			"objects and recipients mismatch. Got %d objects but %d recipients",
+			len(objects),
+			len(recipients),
+		)
+	}
+	if len(objects) == 0 {
+		return errors.New("objects is empty")
+	}
+	var (
+		recipientObjects     = make(map[SuiAddress][]Argument)
+		recipientMapKeyIndex []SuiAddress
+	)
+	for i, recipient := range recipients {
+		if _, ok := recipientObjects[recipient]; !ok {
+			recipientMapKeyIndex = append(recipientMapKeyIndex, recipient)
+		}
+		recipientObjects[recipient] = append(recipientObjects[recipient], objects[i])
+	}
+	for _, recipient := range recipientMapKeyIndex {
+		err := p.TransferObjects(recipientObjects[recipient], recipient)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TransferToZero transfers the owned object to the address 0x0, nobody can use the object afterwards.
+// Use BurnObject instead if the package of the object provides a burn function, which also reclaims the storage rebate
+func (p *ProgrammableTransactionBuilder) TransferToZero(object ObjectRef) error {
+	if p.isSharedInput(object.ObjectId) {
+		return fmt.Errorf("object %v is a shared input and can not be transferred", object.ObjectId)
+	}
+	objArg, err := p.Obj(ObjectArg{ImmOrOwnedObject: &object})
+	if err != nil {
+		return err
+	}
+	return p.TransferObjects([]Argument{objArg}, SuiAddress{})
+}
+
+// BurnObject calls `packageID::module::function` with the object as the only argument,
+// usually a function which unpacks the object and deletes its UID. The object can not be shared.
+func (p *ProgrammableTransactionBuilder) BurnObject(
+	object ObjectRef,
+	packageID ObjectID,
+	module move_types.Identifier,
+	function move_types.Identifier,
+	typeArguments []move_types.TypeTag,
+) error {
+	if p.isSharedInput(object.ObjectId) {
+		return fmt.Errorf("object %v is a shared input and can not be burned", object.ObjectId)
+	}
+	return p.MoveCall(
+		packageID, module, function, typeArguments, []CallArg{
+			{Object: &ObjectArg{ImmOrOwnedObject: &object}},
+		},
+	)
+}
+
+// ZeroCoin calls `0x2::coin::zero<coinType>` and returns the zero coin, which must be used by a later command,
+// e.g. DestroyZeroCoin or a merge
+func (p *ProgrammableTransactionBuilder) ZeroCoin(coinType string) (Argument, error) {
+	typeTag, err := parseCoinTypeArgument(coinType)
+	if err != nil {
+		return Argument{}, err
+	}
+	return p.Command(
+		Command{
+			MoveCall: &ProgrammableMoveCall{
+				Package:       *SuiFrameworkPackageId,
+				Module:        "coin",
+				Function:      "zero",
+				TypeArguments: []move_types.TypeTag{*typeTag},
+			},
+		},
+	), nil
+}
+
+// DestroyZeroCoin calls `0x2::coin::destroy_zero<coinType>` with the coin, the execution aborts if the coin is not zero
+func (p *ProgrammableTransactionBuilder) DestroyZeroCoin(coinType string, coin Argument) error {
+	typeTag, err := parseCoinTypeArgument(coinType)
+	if err != nil {
+		return err
+	}
+	p.Command(
+		Command{
+			MoveCall: &ProgrammableMoveCall{
+				Package:       *SuiFrameworkPackageId,
+				Module:        "coin",
+				Function:      "destroy_zero",
+				TypeArguments: []move_types.TypeTag{*typeTag},
+				Arguments:     []Argument{coin},
+			},
+		},
+	)
+	return nil
+}
+
+// parseCoinTypeArgument parses the T of `Coin<T>`, e.g. 0x2::sui::SUI, which must be a struct
+func parseCoinTypeArgument(coinType string) (*move_types.TypeTag, error) {
+	if coinType == "" {
+		return nil, errors.New("coin type argument is required")
+	}
+	typeTag, err := move_types.ParseTypeTag(coinType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coin type %v: %w", coinType, err)
+	}
+	if typeTag.Struct == nil {
+		return nil, fmt.Errorf("invalid coin type %v: not a struct", coinType)
+	}
+	return typeTag, nil
+}
+
+func (p *ProgrammableTransactionBuilder) isSharedInput(id ObjectID) bool {
+	input, ok := p.Inputs[BuilderArg{Object: &id}.String()]
+	return ok && input.Object != nil && input.Object.SharedObject != nil
+}
+
 func (p *ProgrammableTransactionBuilder) TransferSui(recipient SuiAddress, amount *uint64) error {
 	recArg, err := p.Pure(recipient)
 	if err != nil {
@@ -299,6 +527,112 @@ func (p *ProgrammableTransactionBuilder) MoveCall(
 	return nil
 }
 
+// PureArg the BCS bytes of a pure argument of MoveCallWithArgs, which are used as is, for the types which can't be
+// inferred from the Go values, e.g. u128, u256 or Option
+type PureArg []byte
+
+// MoveCallWithArgs calls the function as MoveCall, the arguments can be the Go values of the pure arguments, whose
+// BCS encoding is inferred from the type:
+//   - bool: bool
+//   - uint8, uint16, uint32, uint64: u8, u16, u32, u64
+//   - int, uint: u64, int must not be negative
+//   - string: vector<u8> of the utf8 bytes, for `std::string::String` and `std::ascii::String`
+//   - []byte: vector<u8>
+//   - SuiAddress (ObjectID): address, also for `0x2::object::ID`
+//   - []bool, []uint16, []uint32, []uint64, []string, [][]byte, []SuiAddress: vector of the element type
+//   - PureArg: the bytes as is
+//
+// The Argument, CallArg and ObjectArg values are used as the arguments of MoveCall, the other types, e.g. the signed
+// ints other than int or *big.Int, are ambiguous and rejected, use PureArg with the explicit BCS bytes instead
+func (p *ProgrammableTransactionBuilder) MoveCallWithArgs(
+	packageID ObjectID,
+	module move_types.Identifier,
+	function move_types.Identifier,
+	typeArguments []move_types.TypeTag,
+	args []any,
+) error {
+	// every argument is encoded before any input is added, a rejected call leaves the builder unchanged
+	inputs := make([]any, len(args))
+	for i, v := range args {
+		switch arg := v.(type) {
+		case Argument, ObjectArg:
+			inputs[i] = arg
+		case CallArg:
+			if arg.Pure == nil && arg.Object == nil {
+				return fmt.Errorf("argument %d: this callArg is nil", i)
+			}
+			inputs[i] = arg
+		case PureArg:
+			inputs[i] = arg
+		default:
+			data, err := inferPureBytes(v)
+			if err != nil {
+				return fmt.Errorf("argument %d: %w", i, err)
+			}
+			inputs[i] = PureArg(data)
+		}
+	}
+
+	// the object inputs may still conflict with the existing ones
+	inputsBefore := make(map[string]CallArg, len(p.Inputs))
+	for k, v := range p.Inputs {
+		inputsBefore[k] = v
+	}
+	keyOrderBefore := len(p.InputsKeyOrder)
+	arguments := make([]Argument, 0, len(inputs))
+	for i, input := range inputs {
+		var (
+			argument Argument
+			err      error
+		)
+		switch arg := input.(type) {
+		case Argument:
+			argument = arg
+		case CallArg:
+			argument, err = p.Input(arg)
+		case ObjectArg:
+			argument, err = p.Obj(arg)
+		case PureArg:
+			argument = p.pureBytes(arg, false)
+		}
+		if err != nil {
+			p.Inputs, p.InputsKeyOrder = inputsBefore, p.InputsKeyOrder[:keyOrderBefore]
+			return fmt.Errorf("argument %d: %w", i, err)
+		}
+		arguments = append(arguments, argument)
+	}
+	p.Command(
+		Command{
+			MoveCall: &ProgrammableMoveCall{
+				Package:       packageID,
+				Module:        module,
+				Function:      function,
+				TypeArguments: typeArguments,
+				Arguments:     arguments,
+			},
+		},
+	)
+	return nil
+}
+
+// inferPureBytes encodes the Go value of a pure argument, see MoveCallWithArgs
+func inferPureBytes(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case int:
+		if v < 0 {
+			return nil, fmt.Errorf("negative int %d can't be encoded as u64", v)
+		}
+		return bcs.Marshal(uint64(v))
+	case uint:
+		return bcs.Marshal(uint64(v))
+	case bool, uint8, uint16, uint32, uint64, string, []byte, SuiAddress,
+		[]bool, []uint16, []uint32, []uint64, []string, [][]byte, []SuiAddress:
+		return bcs.Marshal(v)
+	default:
+		return nil, fmt.Errorf("can't infer the move type of %T, use PureArg with the BCS bytes", value)
+	}
+}
+
 func (p *ProgrammableTransactionBuilder) PaySui(
 	recipients []SuiAddress,
 	amounts []uint64,
@@ -1,6 +1,7 @@
 package sui_types
 
 import (
+	"github.com/coming-chat/go-sui/v2/lib"
 	"github.com/coming-chat/go-sui/v2/move_types"
 	"github.com/fardream/go-bcs/bcs"
 	"github.com/stretchr/testify/require"
@@ -34,6 +35,424 @@ func TestTransferSui(t *testing.T) {
 	var reTx = TransactionData{}
 	_, err = bcs.Unmarshal(txByte, &reTx)
 	require.NoError(t, err)
+	reTxByte, err := bcs.Marshal(reTx)
+	require.NoError(t, err)
+	require.Equal(t, txByte, reTxByte)
 
 	t.Logf("%x", txByte)
 }
+
+func TestTransactionData_TransactionBytes(t *testing.T) {
+	ptb := NewProgrammableTransactionBuilder()
+	amount := uint64(100000)
+	err := ptb.TransferSui(SuiAddress{1}, &amount)
+	require.NoError(t, err)
+	tx := NewProgrammable(SuiAddress{1}, []*ObjectRef{{ObjectId: ObjectID{2}, Version: 1}}, ptb.Finish(), 10000000, 1000)
+	txBytes, err := tx.TransactionBytes()
+	require.NoError(t, err)
+	expected, err := bcs.Marshal(tx)
+	require.NoError(t, err)
+	require.Equal(t, expected, txBytes)
+
+	// the transaction data built by hand without the expiration
+	tx = TransactionData{
+		V1: &TransactionDataV1{
+			Kind:    tx.V1.Kind,
+			Sender:  tx.V1.Sender,
+			GasData: tx.V1.GasData,
+		},
+	}
+	_, err = bcs.Marshal(tx)
+	require.Error(t, err)
+	txBytes, err = tx.TransactionBytes()
+	require.NoError(t, err)
+	require.Equal(t, expected, txBytes)
+	require.Nil(t, tx.V1.Expiration.None)
+
+	_, err = TransactionData{}.TransactionBytes()
+	require.Error(t, err)
+	_, err = TransactionData{V1: &TransactionDataV1{}}.TransactionBytes()
+	require.Error(t, err)
+}
+
+func TestTransactionKind_Type(t *testing.T) {
+	ptb := NewProgrammableTransactionBuilder()
+	tx := NewProgrammable(SuiAddress{}, nil, ptb.Finish(), 0, 0)
+	require.Equal(t, TransactionKindProgrammableTransaction, tx.Kind())
+	require.False(t, tx.V1.Kind.IsSystemTx())
+
+	kind := TransactionKind{ConsensusCommitPrologue: &ConsensusCommitPrologue{}}
+	require.Equal(t, TransactionKindConsensusCommitPrologue, kind.Type())
+	require.True(t, kind.IsSystemTx())
+	require.Equal(t, TransactionKindUnknown, TransactionData{}.Kind())
+}
+
+func TestConsensusCommitPrologueV3_BCS(t *testing.T) {
+	digest, err := NewDigest("HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn")
+	require.NoError(t, err)
+	subDagIndex := uint64(3)
+	kind := TransactionKind{
+		ConsensusCommitPrologueV3: &ConsensusCommitPrologueV3{
+			Epoch:                 100,
+			Round:                 21,
+			SubDagIndex:           &subDagIndex,
+			CommitTimestampMs:     1690000000000,
+			ConsensusCommitDigest: *digest,
+			ConsensusDeterminedVersionAssignments: ConsensusDeterminedVersionAssignments{
+				CancelledTransactions: &[]struct {
+					Digest             TransactionDigest
+					VersionAssignments []struct {
+						ObjectId       ObjectID
+						SequenceNumber SequenceNumber
+					}
+				}{},
+			},
+		},
+	}
+	data, err := bcs.Marshal(kind)
+	require.NoError(t, err)
+	require.Equal(t, byte(8), data[0])
+
+	var decoded TransactionKind
+	_, err = bcs.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, TransactionKindConsensusCommitPrologueV3, decoded.Type())
+	require.Equal(t, kind, decoded)
+	require.Equal(t, uint64(1690000000000), decoded.CommitPrologue().CommitTimestampMs)
+}
+
+func TestBatchTransferObjects(t *testing.T) {
+	a, err := NewAddressFromHex("0xa")
+	require.NoError(t, err)
+	b, err := NewAddressFromHex("0xb")
+	require.NoError(t, err)
+	ptb := NewProgrammableTransactionBuilder()
+	amount1, err := ptb.Pure(uint64(1))
+	require.NoError(t, err)
+	amount2, err := ptb.Pure(uint64(2))
+	require.NoError(t, err)
+	split := ptb.Command(
+		Command{
+			SplitCoins: &struct {
+				Argument  Argument
+				Arguments []Argument
+			}{Argument: Argument{GasCoin: &lib.EmptyEnum{}}, Arguments: []Argument{amount1, amount2}},
+		},
+	)
+	nested := func(i uint16) Argument {
+		return Argument{NestedResult: &struct {
+			Result1 uint16
+			Result2 uint16
+		}{Result1: *split.Result, Result2: i}}
+	}
+	nft, err := ptb.Obj(ObjectArg{ImmOrOwnedObject: &ObjectRef{ObjectId: *b}})
+	require.NoError(t, err)
+
+	err = ptb.BatchTransferObjects([]Argument{nested(0), nft, nested(1)}, []SuiAddress{*a, *b, *a})
+	require.NoError(t, err)
+	pt := ptb.Finish()
+	require.Len(t, pt.Commands, 3)
+	require.Equal(t, []Argument{nested(0), nested(1)}, pt.Commands[1].TransferObjects.Arguments)
+	require.Equal(t, []Argument{nft}, pt.Commands[2].TransferObjects.Arguments)
+
+	err = ptb.TransferObjects(nil, *a)
+	require.Error(t, err)
+	err = ptb.BatchTransferObjects([]Argument{nft}, []SuiAddress{*a, *b})
+	require.ErrorContains(t, err, "Got 1 objects but 2 recipients")
+	err = ptb.BatchTransferObjects([]Argument{nested(0), nested(1)}, []SuiAddress{*a})
+	require.ErrorContains(t, err, "Got 2 objects but 1 recipients")
+	require.Len(t, ptb.Finish().Commands, 3)
+}
+
+func TestEncodePureByteVectors(t *testing.T) {
+	long := make([]byte, 200)
+	tests := []struct {
+		name    string
+		vectors [][]byte
+		want    []byte
+	}{
+		{name: "empty outer", vectors: [][]byte{}, want: []byte{0}},
+		{name: "nil outer", vectors: nil, want: []byte{0}},
+		{name: "empty inner", vectors: [][]byte{{}, {}}, want: []byte{2, 0, 0}},
+		{name: "mixed", vectors: [][]byte{{1, 2}, {}, {3}}, want: []byte{3, 2, 1, 2, 0, 1, 3}},
+		{name: "long inner", vectors: [][]byte{long}, want: append([]byte{1, 0xc8, 0x01}, long...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EncodePureByteVectors(tt.vectors)
+			require.Equal(t, tt.want, got)
+			if tt.vectors != nil {
+				expected, err := bcs.Marshal(tt.vectors)
+				require.NoError(t, err)
+				require.Equal(t, expected, got)
+			}
+		})
+	}
+
+	ptb := NewProgrammableTransactionBuilder()
+	arg := ptb.PureByteVectors([][]byte{{1, 2}})
+	pt := ptb.Finish()
+	require.Equal(t, []byte{1, 2, 1, 2}, []byte(*pt.Inputs[*arg.Input].Pure))
+}
+
+func TestTransferToZero(t *testing.T) {
+	object := ObjectRef{ObjectId: ObjectID{1}, Version: 1, Digest: make([]byte, 32)}
+	ptb := NewProgrammableTransactionBuilder()
+	err := ptb.TransferToZero(object)
+	require.NoError(t, err)
+	err = ptb.BurnObject(object, ObjectID{2}, "nft", "burn", nil)
+	require.NoError(t, err)
+	pt := ptb.Finish()
+	require.Len(t, pt.Commands, 2)
+	require.Equal(t, SuiAddress{}.Data(), []byte(*pt.Inputs[*pt.Commands[0].TransferObjects.Argument.Input].Pure))
+	require.Equal(t, pt.Commands[0].TransferObjects.Arguments[0], pt.Commands[1].MoveCall.Arguments[0])
+
+	ptb = NewProgrammableTransactionBuilder()
+	_, err = ptb.Obj(
+		ObjectArg{
+			SharedObject: &struct {
+				Id                   ObjectID
+				InitialSharedVersion SequenceNumber
+				Mutable              bool
+			}{Id: object.ObjectId, InitialSharedVersion: 1, Mutable: true},
+		},
+	)
+	require.NoError(t, err)
+	require.Error(t, ptb.TransferToZero(object))
+	require.Error(t, ptb.BurnObject(object, ObjectID{2}, "nft", "burn", nil))
+}
+
+func TestSharedObj(t *testing.T) {
+	ptb := NewProgrammableTransactionBuilder()
+	arg1, err := ptb.SharedObj(*SuiClockObjectId, SuiClockObjectSharedVersion, false)
+	require.NoError(t, err)
+	arg2, err := ptb.SharedObj(*SuiClockObjectId, SuiClockObjectSharedVersion, true)
+	require.NoError(t, err)
+	require.Equal(t, arg1, arg2)
+	pt := ptb.Finish()
+	require.Len(t, pt.Inputs, 1)
+	// the mutability is upgraded if any usage is mutable
+	require.True(t, pt.Inputs[0].Object.SharedObject.Mutable)
+	require.Equal(t, SuiClockObjectSharedVersion, pt.Inputs[0].Object.SharedObject.InitialSharedVersion)
+}
+
+func TestNewTransferObjectsWithGasCoins(t *testing.T) {
+	gasCoin := &ObjectRef{ObjectId: ObjectID{1}, Version: 1, Digest: make([]byte, 32)}
+	coin := &ObjectRef{ObjectId: ObjectID{2}, Version: 1, Digest: make([]byte, 32)}
+	tx, err := NewTransferObjectsWithGasCoins(SuiAddress{3}, SuiAddress{4}, []*ObjectRef{coin}, []*ObjectRef{gasCoin}, 1000, 1)
+	require.NoError(t, err)
+	require.Equal(t, []*ObjectRef{gasCoin}, tx.V1.GasData.Payment)
+	pt := tx.V1.Kind.ProgrammableTransaction
+	require.Len(t, pt.Commands, 1)
+	require.Equal(t, coin, pt.Inputs[*pt.Commands[0].TransferObjects.Arguments[0].Input].Object.ImmOrOwnedObject)
+
+	_, err = NewTransferObjectsWithGasCoins(
+		SuiAddress{3}, SuiAddress{4}, []*ObjectRef{coin, gasCoin}, []*ObjectRef{gasCoin}, 1000, 1,
+	)
+	require.ErrorIs(t, err, ErrGasCoinOverlap)
+	_, err = NewTransferObjectsWithGasCoins(SuiAddress{3}, SuiAddress{4}, []*ObjectRef{coin}, nil, 1000, 1)
+	require.Error(t, err)
+	require.Error(t, CheckGasCoinOverlap(ProgrammableTransaction{}, []*ObjectRef{gasCoin, gasCoin}))
+}
+
+func TestCanonicalTransactionJSON(t *testing.T) {
+	gasCoin := &ObjectRef{ObjectId: ObjectID{31: 1}, Version: 1, Digest: make([]byte, 32)}
+	ptb := NewProgrammableTransactionBuilder()
+	require.NoError(t, ptb.TransferSui(SuiAddress{31: 4}, nil))
+	tx := NewProgrammable(SuiAddress{31: 3}, []*ObjectRef{gasCoin}, ptb.Finish(), 1000, 1)
+
+	data, err := CanonicalTransactionJSON(tx)
+	require.NoError(t, err)
+	require.Equal(
+		t, `{"V1":{"Expiration":{"None":{}},"GasData":{"Budget":1000,"Owner":"0x0000000000000000000000000000000000000000000000000000000000000003",`+
+			`"Payment":[{"digest":"11111111111111111111111111111111","objectId":"0x0000000000000000000000000000000000000000000000000000000000000001","version":1}],`+
+			`"Price":1},"Kind":{"ProgrammableTransaction":{"Commands":[{"TransferObjects":{"Argument":{"Input":0},"Arguments":[{"GasCoin":{}}]}}],`+
+			`"Inputs":[{"Pure":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQ="}]}},"Sender":"0x0000000000000000000000000000000000000000000000000000000000000003"}}`,
+		string(data),
+	)
+
+	// the unset expiration is the default None
+	tx.V1.Expiration = TransactionExpiration{}
+	again, err := CanonicalTransactionJSON(tx)
+	require.NoError(t, err)
+	require.Equal(t, data, again)
+
+	_, err = CanonicalTransactionJSON(TransactionData{})
+	require.Error(t, err)
+
+	object := &ObjectRef{ObjectId: ObjectID{31: 2}, Version: 1, Digest: make([]byte, 32)}
+	tx, err = NewTransferObjectsWithGasCoins(SuiAddress{31: 3}, SuiAddress{31: 4}, []*ObjectRef{object}, []*ObjectRef{gasCoin}, 1000, 1)
+	require.NoError(t, err)
+	data, err = CanonicalTransactionJSON(tx)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"Inputs":[{"Pure":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQ="},{"Object":{"ImmOrOwnedObject":`)
+}
+
+func TestTransactionDataWithoutGas_FinalizeGas(t *testing.T) {
+	coin := &ObjectRef{ObjectId: ObjectID{31: 2}, Version: 1, Digest: make([]byte, 32)}
+	ptb := NewProgrammableTransactionBuilder()
+	require.NoError(t, ptb.TransferObject(SuiAddress{31: 4}, []*ObjectRef{coin}))
+	sender := SuiAddress{31: 3}
+	unfinished := NewProgrammableWithoutGas(sender, ptb.Finish())
+
+	kindBytes, err := unfinished.KindBytes()
+	require.NoError(t, err)
+	expectedKind, err := bcs.Marshal(TransactionKind{ProgrammableTransaction: unfinished.Kind.ProgrammableTransaction})
+	require.NoError(t, err)
+	require.Equal(t, expectedKind, kindBytes)
+
+	sponsor := SuiAddress{31: 5}
+	gasCoin := &ObjectRef{ObjectId: ObjectID{31: 1}, Version: 1, Digest: make([]byte, 32)}
+	tx, err := unfinished.FinalizeGas(GasData{Payment: []*ObjectRef{gasCoin}, Owner: sponsor, Price: 1000, Budget: 1000000})
+	require.NoError(t, err)
+	require.Equal(t, sponsor, tx.V1.GasData.Owner)
+	expected, err := NewProgrammableAllowSponsor(
+		sender, []*ObjectRef{gasCoin}, *unfinished.Kind.ProgrammableTransaction, 1000000, 1000, sponsor,
+	).TransactionBytes()
+	require.NoError(t, err)
+	txBytes, err := tx.TransactionBytes()
+	require.NoError(t, err)
+	require.Equal(t, expected, txBytes)
+
+	tx, err = unfinished.FinalizeGas(GasData{Payment: []*ObjectRef{gasCoin}, Price: 1000, Budget: 1000000})
+	require.NoError(t, err)
+	require.Equal(t, sender, tx.V1.GasData.Owner)
+
+	_, err = unfinished.FinalizeGas(GasData{Payment: []*ObjectRef{coin}, Price: 1000, Budget: 1000000})
+	require.ErrorIs(t, err, ErrGasCoinOverlap)
+	_, err = unfinished.FinalizeGas(GasData{Price: 1000, Budget: 1000000})
+	require.Error(t, err)
+	_, err = unfinished.FinalizeGas(GasData{Payment: []*ObjectRef{gasCoin}, Budget: 1000000})
+	require.Error(t, err)
+}
+
+func TestProgrammableTransactionBuilder_NestedResult(t *testing.T) {
+	ptb := NewProgrammableTransactionBuilder()
+	_, err := ptb.Result(0)
+	require.Error(t, err)
+
+	amounts := []uint64{1, 2}
+	var amountArgs []Argument
+	for _, amount := range amounts {
+		arg, err := ptb.Pure(amount)
+		require.NoError(t, err)
+		amountArgs = append(amountArgs, arg)
+	}
+	split := ptb.Command(
+		Command{
+			SplitCoins: &struct {
+				Argument  Argument
+				Arguments []Argument
+			}{Argument: Argument{GasCoin: &lib.EmptyEnum{}}, Arguments: amountArgs},
+		},
+	)
+	result, err := ptb.Result(0)
+	require.NoError(t, err)
+	require.Equal(t, split, result)
+
+	second, err := ptb.NestedResult(0, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint16(0), second.NestedResult.Result1)
+	require.Equal(t, uint16(1), second.NestedResult.Result2)
+	_, err = ptb.NestedResult(0, 2)
+	require.Error(t, err)
+	_, err = ptb.NestedResult(1, 0)
+	require.Error(t, err)
+
+	require.NoError(t, ptb.TransferObjects([]Argument{second}, SuiAddress{1}))
+	_, err = ptb.NestedResult(1, 0)
+	require.Error(t, err)
+
+	err = ptb.MoveCall(ObjectID{2}, "pool", "swap", nil, []CallArg{})
+	require.NoError(t, err)
+	// the results of a move call are unknown by the builder
+	_, err = ptb.NestedResult(2, 3)
+	require.NoError(t, err)
+}
+
+func TestProgrammableTransactionBuilder_ZeroCoin(t *testing.T) {
+	ptb := NewProgrammableTransactionBuilder()
+	_, err := ptb.ZeroCoin("")
+	require.Error(t, err)
+	_, err = ptb.ZeroCoin("u64")
+	require.Error(t, err)
+	require.Error(t, ptb.DestroyZeroCoin("", Argument{GasCoin: &lib.EmptyEnum{}}))
+	require.Empty(t, ptb.Commands)
+
+	zero, err := ptb.ZeroCoin("0x2::sui::SUI")
+	require.NoError(t, err)
+	require.NoError(t, ptb.DestroyZeroCoin("0x2::sui::SUI", zero))
+	pt := ptb.Finish()
+	require.Len(t, pt.Commands, 2)
+
+	call := pt.Commands[0].MoveCall
+	require.Equal(t, *SuiFrameworkPackageId, call.Package)
+	require.Equal(t, move_types.Identifier("coin"), call.Module)
+	require.Equal(t, move_types.Identifier("zero"), call.Function)
+	require.Len(t, call.TypeArguments, 1)
+	require.Equal(t, "0x2::sui::SUI", call.TypeArguments[0].Struct.String())
+	require.Empty(t, call.Arguments)
+
+	call = pt.Commands[1].MoveCall
+	require.Equal(t, move_types.Identifier("destroy_zero"), call.Function)
+	require.Equal(t, []Argument{zero}, call.Arguments)
+	_, err = bcs.Marshal(pt)
+	require.NoError(t, err)
+}
+
+func TestProgrammableTransactionBuilder_MoveCallWithArgs(t *testing.T) {
+	address := SuiAddress{0xab}
+	tests := []struct {
+		value any
+		want  []byte
+	}{
+		{value: true, want: []byte{1}},
+		{value: uint8(7), want: []byte{7}},
+		{value: uint16(0x0102), want: []byte{2, 1}},
+		{value: uint32(1), want: []byte{1, 0, 0, 0}},
+		{value: uint64(1), want: []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+		{value: 2, want: []byte{2, 0, 0, 0, 0, 0, 0, 0}},
+		{value: uint(3), want: []byte{3, 0, 0, 0, 0, 0, 0, 0}},
+		{value: "sui", want: []byte{3, 's', 'u', 'i'}},
+		{value: []byte{1, 2}, want: []byte{2, 1, 2}},
+		{value: address, want: address[:]},
+		{value: []uint64{1}, want: []byte{1, 1, 0, 0, 0, 0, 0, 0, 0}},
+		{value: []string{"a", "bc"}, want: []byte{2, 1, 'a', 2, 'b', 'c'}},
+		{value: [][]byte{{1}}, want: []byte{1, 1, 1}},
+		{value: PureArg{0xff, 0xff}, want: []byte{0xff, 0xff}},
+	}
+	ptb := NewProgrammableTransactionBuilder()
+	args := []any{Argument{GasCoin: &lib.EmptyEnum{}}}
+	for _, tt := range tests {
+		args = append(args, tt.value)
+	}
+	err := ptb.MoveCallWithArgs(ObjectID{2}, "pool", "swap", nil, args)
+	require.NoError(t, err)
+	pt := ptb.Finish()
+	call := pt.Commands[0].MoveCall
+	require.Len(t, call.Arguments, len(tests)+1)
+	require.NotNil(t, call.Arguments[0].GasCoin)
+	for i, tt := range tests {
+		input := pt.Inputs[*call.Arguments[i+1].Input]
+		require.Equal(t, tt.want, *input.Pure, "%T", tt.value)
+	}
+
+	// the rejected calls add no inputs
+	_, err = ptb.SharedObj(ObjectID{9}, 1, false)
+	require.NoError(t, err)
+	inputs, commands := ptb.Finish().Inputs, len(ptb.Commands)
+	conflict := ObjectArg{
+		SharedObject: &struct {
+			Id                   ObjectID
+			InitialSharedVersion SequenceNumber
+			Mutable              bool
+		}{Id: ObjectID{9}, InitialSharedVersion: 2},
+	}
+	for _, value := range []any{-1, int64(1), 1.5, nil, &address, CallArg{}, conflict} {
+		err = ptb.MoveCallWithArgs(ObjectID{2}, "pool", "swap", nil, []any{uint64(12345), value})
+		require.Error(t, err, "%T", value)
+		require.Contains(t, err.Error(), "argument 1")
+		require.Equal(t, inputs, ptb.Finish().Inputs, "%T", value)
+		require.Len(t, ptb.Commands, commands)
+	}
+}
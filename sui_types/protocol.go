@@ -0,0 +1,38 @@
+package sui_types
+
+// ProtocolFeature a feature of the transaction which is only valid if the feature flag of the protocol config is
+// enabled, see `sui_getProtocolConfig`
+type ProtocolFeature struct {
+	Name        string
+	FeatureFlag string
+}
+
+var (
+	ProtocolFeaturePackageUpgrade = ProtocolFeature{Name: "package upgrade", FeatureFlag: "package_upgrades"}
+	ProtocolFeatureRandom         = ProtocolFeature{Name: "on-chain randomness", FeatureFlag: "random_beacon"}
+)
+
+// RequiredProtocolFeatures returns the features of the programmable transaction gated by the feature flags,
+// each feature is listed once:
+//   - ProtocolFeaturePackageUpgrade for the Upgrade command
+//   - ProtocolFeatureRandom for the shared input of the Random object `0x8`
+func (t TransactionData) RequiredProtocolFeatures() []ProtocolFeature {
+	if t.V1 == nil || t.V1.Kind.ProgrammableTransaction == nil {
+		return nil
+	}
+	pt := t.V1.Kind.ProgrammableTransaction
+	var features []ProtocolFeature
+	for _, command := range pt.Commands {
+		if command.Upgrade != nil {
+			features = append(features, ProtocolFeaturePackageUpgrade)
+			break
+		}
+	}
+	for _, input := range pt.Inputs {
+		if input.Object != nil && input.Object.SharedObject != nil && input.Object.SharedObject.Id == *SuiRandomObjectId {
+			features = append(features, ProtocolFeatureRandom)
+			break
+		}
+	}
+	return features
+}
@@ -0,0 +1,232 @@
+package sui_types
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coming-chat/go-sui/v2/crypto"
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrSenderMismatch the sender of the transaction or its signers is not the expected one
+	ErrSenderMismatch = errors.New("sender mismatch")
+)
+
+// VerifyTransactionSignature verifies the serialized signature(flag || signature || public key) of the BCS bytes of
+// TransactionData signed with the default intent
+func VerifyTransactionSignature(txBytes []byte, signature Signature) error {
+	return verifyIntentSignature(DefaultIntent(), txBytes, signature)
+}
+
+// VerifyIntentSignature verifies the serialized signature of the BCS bytes of a value signed with the intent,
+// see SuiKeyPair.SignWithIntent
+func VerifyIntentSignature(intent Intent, bcsBytes []byte, signature Signature) error {
+	return verifyIntentSignature(intent, bcsBytes, signature)
+}
+
+// VerifyPersonalMessage verifies the base64 serialized signature of the message signed with the PersonalMessage intent,
+// and returns the address of the signer, see SuiKeyPair.SignPersonalMessage
+func VerifyPersonalMessage(message []byte, signature string) (SuiAddress, error) {
+	sig, err := NewSignatureFromBase64(signature)
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	messageBytes, err := bcs.Marshal(message)
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	err = verifyIntentSignature(PersonalMessageIntent(), messageBytes, sig)
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	return sig.signerAddress()
+}
+
+// PreflightExecute checks the transaction and its signatures locally before executing them, which catches the signing
+// bugs without a network call:
+//   - txBytes is the BCS bytes of TransactionData, and its sender is expectedSender
+//   - every signature is valid for txBytes with the default intent
+//   - the signers are the sender and the gas owner if it is sponsored, without missing or extra signers
+func PreflightExecute(txBytes []byte, signatures []Signature, expectedSender SuiAddress) error {
+	tx, err := DecodeTransactionData(txBytes)
+	if err != nil {
+		return err
+	}
+	if tx.V1.Sender != expectedSender {
+		return fmt.Errorf("%w: the sender is %v, expected %v", ErrSenderMismatch, tx.V1.Sender, expectedSender)
+	}
+
+	required := map[SuiAddress]bool{tx.V1.Sender: false, tx.V1.GasData.Owner: false}
+	for i, signature := range signatures {
+		err = VerifyTransactionSignature(txBytes, signature)
+		if err != nil {
+			return fmt.Errorf("signature %d: %w", i, err)
+		}
+		signer, err := signature.signerAddress()
+		if err != nil {
+			return fmt.Errorf("signature %d: %w", i, err)
+		}
+		signed, ok := required[signer]
+		if !ok {
+			return fmt.Errorf(
+				"%w: signature %d is signed by %v, which is neither the sender nor the gas owner", ErrSenderMismatch, i,
+				signer,
+			)
+		}
+		if signed {
+			return fmt.Errorf("signature %d: duplicated signature of %v", i, signer)
+		}
+		required[signer] = true
+	}
+	for signer, signed := range required {
+		if !signed {
+			return fmt.Errorf("%w: missing the signature of %v", ErrSenderMismatch, signer)
+		}
+	}
+	return nil
+}
+
+// VerifyTransactionSender checks the signature of the received transaction is signed by its sender, so the sender
+// field can't be spoofed, e.g. by a relayer before forwarding the transaction, and returns the sender.
+// ErrSenderMismatch is returned if the signer recovered from the signature is not the sender
+func VerifyTransactionSender(txBytes []byte, signature Signature) (SuiAddress, error) {
+	tx, err := DecodeTransactionData(txBytes)
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	err = VerifyTransactionSignature(txBytes, signature)
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	signer, err := signature.signerAddress()
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	if signer != tx.V1.Sender {
+		return SuiAddress{}, fmt.Errorf("%w: signed by %v, the sender is %v", ErrSenderMismatch, signer, tx.V1.Sender)
+	}
+	return signer, nil
+}
+
+// DecodeTransactionData decodes the BCS bytes of TransactionData, all the bytes must be consumed
+func DecodeTransactionData(txBytes []byte) (*TransactionData, error) {
+	var tx TransactionData
+	n, err := lib.UnmarshalBcs(txBytes, &tx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction data: %w", err)
+	}
+	if n != len(txBytes) || tx.V1 == nil {
+		return nil, errors.New("invalid transaction data")
+	}
+	return &tx, nil
+}
+
+type SignatureVerifyItem struct {
+	TxBytes   []byte
+	Signature Signature
+}
+
+// BatchVerifySignatures verifies the transaction signatures with at most `parallelism` goroutines,
+// the result of each item is at the same index, nil means the signature is valid.
+// NOTE: crypto/ed25519 has no batch verification API, so the ed25519 signatures are verified one by one as well,
+// the throughput comes from the parallelism
+func BatchVerifySignatures(items []SignatureVerifyItem, parallelism int) []error {
+	results := make([]error, len(items))
+	if parallelism <= 1 {
+		for i, item := range items {
+			results[i] = VerifyTransactionSignature(item.TxBytes, item.Signature)
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	indexes := make(chan int)
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = VerifyTransactionSignature(items[i].TxBytes, items[i].Signature)
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+func verifyIntentSignature(intent Intent, message []byte, signature Signature) error {
+	intentMessage, err := bcs.Marshal(NewIntentMessage(intent, rawBcsBytes(message)))
+	if err != nil {
+		return err
+	}
+	digest := hash256(intentMessage)
+	return signature.verify(digest[:])
+}
+
+// verify checks the signature of the message digest
+func (s Signature) verify(digest []byte) error {
+	data, err := s.Bytes()
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return ErrInvalidSignature
+	}
+	scheme, err := NewSignatureScheme(data[0])
+	if err != nil {
+		return err
+	}
+	pubKeySize, err := scheme.PublicKeySize()
+	if err != nil {
+		return err
+	}
+	if len(data) <= pubKeySize+1 {
+		return ErrInvalidSignature
+	}
+	sig, pubKey := data[1:len(data)-pubKeySize], data[len(data)-pubKeySize:]
+
+	var valid bool
+	switch scheme.Flag() {
+	case 0:
+		valid = crypto.VerifyEd25519(pubKey, digest, sig)
+	case 1:
+		valid = crypto.VerifySecp256k1(pubKey, digest, sig)
+	case 2:
+		valid = crypto.VerifySecp256r1(pubKey, digest, sig)
+	default:
+		return fmt.Errorf("unsupported signature scheme %d", scheme.Flag())
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// signerAddress returns the address of the public key in the signature
+func (s Signature) signerAddress() (SuiAddress, error) {
+	data, err := s.Bytes()
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	scheme, err := NewSignatureScheme(data[0])
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	pubKeySize, err := scheme.PublicKeySize()
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	pubKey, err := NewPublicKey(scheme, data[len(data)-pubKeySize:])
+	if err != nil {
+		return SuiAddress{}, err
+	}
+	return pubKey.SuiAddress(), nil
+}
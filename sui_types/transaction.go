@@ -1,6 +1,15 @@
 package sui_types
 
-import "github.com/coming-chat/go-sui/v2/lib"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// ErrGasCoinOverlap a gas payment coin is also an object input of the transaction
+var ErrGasCoinOverlap = errors.New("gas coin is also a transaction input")
 
 var (
 	SuiSystemMut = CallArg{
@@ -40,6 +49,120 @@ func NewProgrammable(
 	return NewProgrammableAllowSponsor(sender, gasPayment, pt, gasBudget, gasPrice, sender)
 }
 
+// NewProgrammableWithGasCoins is NewProgrammable with the gas payment checked by CheckGasCoinOverlap,
+// so the gas coins are exactly the given coins and are not used by the commands
+func NewProgrammableWithGasCoins(
+	sender SuiAddress,
+	gasPayment []*ObjectRef,
+	pt ProgrammableTransaction,
+	gasBudget uint64,
+	gasPrice uint64,
+) (TransactionData, error) {
+	if len(gasPayment) == 0 {
+		return TransactionData{}, errors.New("gas payment is empty")
+	}
+	if err := CheckGasCoinOverlap(pt, gasPayment); err != nil {
+		return TransactionData{}, err
+	}
+	return NewProgrammable(sender, gasPayment, pt, gasBudget, gasPrice), nil
+}
+
+// NewTransferObjectsWithGasCoins transfers the objects, which can be coins of any type including SUI, to recipient
+// and pays the gas with the gas coins, ErrGasCoinOverlap is returned if a gas coin is also transferred
+func NewTransferObjectsWithGasCoins(
+	sender SuiAddress,
+	recipient SuiAddress,
+	objects []*ObjectRef,
+	gasPayment []*ObjectRef,
+	gasBudget uint64,
+	gasPrice uint64,
+) (TransactionData, error) {
+	if len(objects) == 0 {
+		return TransactionData{}, errors.New("objects is empty")
+	}
+	ptb := NewProgrammableTransactionBuilder()
+	if err := ptb.TransferObject(recipient, objects); err != nil {
+		return TransactionData{}, err
+	}
+	return NewProgrammableWithGasCoins(sender, gasPayment, ptb.Finish(), gasBudget, gasPrice)
+}
+
+// CheckGasCoinOverlap returns ErrGasCoinOverlap if any gas coin is an object input of the programmable transaction,
+// the node rejects such transactions, the commands should use the GasCoin argument to spend the gas coin instead
+func CheckGasCoinOverlap(pt ProgrammableTransaction, gasPayment []*ObjectRef) error {
+	gasCoins := make(map[ObjectID]bool, len(gasPayment))
+	for _, ref := range gasPayment {
+		if gasCoins[ref.ObjectId] {
+			return fmt.Errorf("duplicate gas coin %v", ref.ObjectId)
+		}
+		gasCoins[ref.ObjectId] = true
+	}
+	for i, input := range pt.Inputs {
+		if input.Object != nil && gasCoins[input.Object.id()] {
+			return fmt.Errorf("%w: %v is input %d", ErrGasCoinOverlap, input.Object.id(), i)
+		}
+	}
+	return nil
+}
+
+// TransactionDataWithoutGas the transaction without the gas data, which is decided at submit time, e.g. by a sponsor.
+// FinalizeGas attaches the gas data to get the TransactionData to sign, the gas can be estimated with the kind bytes
+type TransactionDataWithoutGas struct {
+	Kind       TransactionKind
+	Sender     SuiAddress
+	Expiration TransactionExpiration
+}
+
+func NewProgrammableWithoutGas(sender SuiAddress, pt ProgrammableTransaction) TransactionDataWithoutGas {
+	return TransactionDataWithoutGas{
+		Kind:       TransactionKind{ProgrammableTransaction: &pt},
+		Sender:     sender,
+		Expiration: TransactionExpiration{None: &lib.EmptyEnum{}},
+	}
+}
+
+// KindBytes returns the BCS bytes of the transaction kind, which are accepted by devInspectTransactionBlock
+func (t TransactionDataWithoutGas) KindBytes() ([]byte, error) {
+	if t.Kind.Type() == TransactionKindUnknown {
+		return nil, errors.New("transaction kind is not set")
+	}
+	return bcs.Marshal(t.Kind)
+}
+
+// FinalizeGas attaches the gas data to the transaction, the gas owner is the sender if it is not set.
+// The gas payment must not be an object input of the programmable transaction, see CheckGasCoinOverlap
+func (t TransactionDataWithoutGas) FinalizeGas(gasData GasData) (TransactionData, error) {
+	if t.Kind.Type() == TransactionKindUnknown {
+		return TransactionData{}, errors.New("transaction kind is not set")
+	}
+	if len(gasData.Payment) == 0 {
+		return TransactionData{}, errors.New("gas payment is empty")
+	}
+	if gasData.Price == 0 || gasData.Budget == 0 {
+		return TransactionData{}, fmt.Errorf("gas price %d and budget %d should be positive", gasData.Price, gasData.Budget)
+	}
+	if t.Kind.ProgrammableTransaction != nil {
+		if err := CheckGasCoinOverlap(*t.Kind.ProgrammableTransaction, gasData.Payment); err != nil {
+			return TransactionData{}, err
+		}
+	}
+	if gasData.Owner == (SuiAddress{}) {
+		gasData.Owner = t.Sender
+	}
+	expiration := t.Expiration
+	if expiration.None == nil && expiration.Epoch == nil {
+		expiration = TransactionExpiration{None: &lib.EmptyEnum{}}
+	}
+	return TransactionData{
+		V1: &TransactionDataV1{
+			Kind:       t.Kind,
+			Sender:     t.Sender,
+			GasData:    gasData,
+			Expiration: expiration,
+		},
+	}, nil
+}
+
 func newWithGasCoinsAllowSponsor(
 	kind TransactionKind,
 	sender SuiAddress,
@@ -64,3 +187,51 @@ func newWithGasCoinsAllowSponsor(
 		},
 	}
 }
+
+// ComputeTransactionDigest returns the digest of the BCS bytes of TransactionData,
+// which is the blake2b hash of "TransactionData::" || txBytes
+func ComputeTransactionDigest(txBytes []byte) TransactionDigest {
+	digest := NewDefaultHash()
+	digest.Write([]byte("TransactionData::"))
+	digest.Write(txBytes)
+	return digest.Sum(nil)
+}
+
+// SenderSignedTransaction the BCS bytes of SenderSignedData is a vector of one SenderSignedTransaction,
+// which is the raw transaction returned with `showRawInput`
+type SenderSignedTransaction struct {
+	IntentMessage IntentMessage[TransactionData]
+	TxSignatures  [][]byte
+}
+
+// ParseSenderSignedData decodes the BCS bytes of SenderSignedData, which always holds one transaction,
+// and returns the BCS bytes of TransactionData and the serialized signatures.
+// NOTE: the bytes are sliced from raw instead of re-encoded, so the digest is computed on the exact signed bytes
+func ParseSenderSignedData(raw []byte) (txBytes []byte, signatures [][]byte, err error) {
+	// the uleb128 length of the vector
+	if len(raw) == 0 || raw[0] != 1 {
+		return nil, nil, errors.New("sender signed data should contain one transaction")
+	}
+	offset := 1
+	var intent Intent
+	n, err := lib.UnmarshalBcs(raw[offset:], &intent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid intent: %w", err)
+	}
+	offset += n
+	var tx TransactionData
+	n, err = lib.UnmarshalBcs(raw[offset:], &tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid transaction data: %w", err)
+	}
+	txBytes = raw[offset : offset+n]
+	offset += n
+	n, err = lib.UnmarshalBcs(raw[offset:], &signatures)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signatures: %w", err)
+	}
+	if offset+n != len(raw) {
+		return nil, nil, errors.New("unexpected trailing bytes in sender signed data")
+	}
+	return txBytes, signatures, nil
+}
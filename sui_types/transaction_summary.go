@@ -0,0 +1,163 @@
+package sui_types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/shopspring/decimal"
+)
+
+// SummarizeTransaction describes what the transaction intends to do, one line per command, e.g.
+// "transfer 5 SUI to 0x..." or "call 0x2::coin::join". Intended for wallet confirmation prompts
+func SummarizeTransaction(txData TransactionData) []string {
+	if txData.V1 == nil {
+		return nil
+	}
+	v1 := txData.V1
+	lines := []string{
+		fmt.Sprintf("sender %v", v1.Sender.String()),
+		fmt.Sprintf("gas budget %v SUI at price %d", formatSui(v1.GasData.Budget), v1.GasData.Price),
+	}
+	pt := v1.Kind.ProgrammableTransaction
+	if pt == nil {
+		return append(lines, fmt.Sprintf("system transaction %v", v1.Kind.Type()))
+	}
+	s := transactionSummarizer{pt: pt}
+	for _, command := range pt.Commands {
+		lines = append(lines, s.command(command))
+	}
+	return lines
+}
+
+type transactionSummarizer struct {
+	pt *ProgrammableTransaction
+}
+
+func (s *transactionSummarizer) command(command Command) string {
+	switch {
+	case command.MoveCall != nil:
+		return fmt.Sprintf(
+			"call %v::%v::%v", command.MoveCall.Package.ShortString(), command.MoveCall.Module,
+			command.MoveCall.Function,
+		)
+	case command.TransferObjects != nil:
+		return fmt.Sprintf(
+			"transfer %v to %v", s.arguments(command.TransferObjects.Arguments),
+			s.address(command.TransferObjects.Argument),
+		)
+	case command.SplitCoins != nil:
+		amounts := make([]string, len(command.SplitCoins.Arguments))
+		for i, arg := range command.SplitCoins.Arguments {
+			amounts[i] = s.amount(command.SplitCoins.Argument, arg)
+		}
+		return fmt.Sprintf("split %v into %v", s.argument(command.SplitCoins.Argument), strings.Join(amounts, ", "))
+	case command.MergeCoins != nil:
+		return fmt.Sprintf(
+			"merge %v into %v", s.arguments(command.MergeCoins.Arguments), s.argument(command.MergeCoins.Argument),
+		)
+	case command.Publish != nil:
+		return fmt.Sprintf("publish package with %d modules", len(command.Publish.Bytes))
+	case command.MakeMoveVec != nil:
+		return fmt.Sprintf("make move vector of %v", s.arguments(command.MakeMoveVec.Arguments))
+	case command.Upgrade != nil:
+		return fmt.Sprintf("upgrade package %v", command.Upgrade.ObjectID.ShortString())
+	default:
+		return "unknown command"
+	}
+}
+
+func (s *transactionSummarizer) arguments(args []Argument) string {
+	descs := make([]string, len(args))
+	for i, arg := range args {
+		descs[i] = s.argument(arg)
+	}
+	return strings.Join(descs, ", ")
+}
+
+func (s *transactionSummarizer) argument(arg Argument) string {
+	switch {
+	case arg.GasCoin != nil:
+		return "gas coin"
+	case arg.Input != nil:
+		input := s.input(*arg.Input)
+		switch {
+		case input != nil && input.Object != nil:
+			id := input.Object.id()
+			return fmt.Sprintf("object %v", id.ShortString())
+		case input != nil && input.Pure != nil:
+			return fmt.Sprintf("pure 0x%v", hex.EncodeToString(*input.Pure))
+		default:
+			return fmt.Sprintf("input %d", *arg.Input)
+		}
+	case arg.Result != nil:
+		return fmt.Sprintf("result of command %d", *arg.Result)
+	case arg.NestedResult != nil:
+		split := s.splitCoins(arg.NestedResult.Result1)
+		if split == nil || int(arg.NestedResult.Result2) >= len(split.Arguments) {
+			return fmt.Sprintf("result %d of command %d", arg.NestedResult.Result2, arg.NestedResult.Result1)
+		}
+		return s.amount(split.Argument, split.Arguments[arg.NestedResult.Result2])
+	default:
+		return "unknown argument"
+	}
+}
+
+// amount describes a coin of `amountArg` split from `coin`
+func (s *transactionSummarizer) amount(coin Argument, amountArg Argument) string {
+	amount, ok := s.pureU64(amountArg)
+	if !ok {
+		return fmt.Sprintf("%v from %v", s.argument(amountArg), s.argument(coin))
+	}
+	if coin.GasCoin != nil {
+		return fmt.Sprintf("%v SUI", formatSui(amount))
+	}
+	return fmt.Sprintf("%d from %v", amount, s.argument(coin))
+}
+
+func (s *transactionSummarizer) address(arg Argument) string {
+	if arg.Input != nil {
+		input := s.input(*arg.Input)
+		if input != nil && input.Pure != nil && len(*input.Pure) == move_types.SuiAddressLen {
+			var addr SuiAddress
+			copy(addr[:], *input.Pure)
+			return addr.String()
+		}
+	}
+	return s.argument(arg)
+}
+
+func (s *transactionSummarizer) pureU64(arg Argument) (uint64, bool) {
+	if arg.Input == nil {
+		return 0, false
+	}
+	input := s.input(*arg.Input)
+	if input == nil || input.Pure == nil || len(*input.Pure) != 8 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(*input.Pure), true
+}
+
+func (s *transactionSummarizer) input(index uint16) *CallArg {
+	if int(index) >= len(s.pt.Inputs) {
+		return nil
+	}
+	return &s.pt.Inputs[index]
+}
+
+func (s *transactionSummarizer) splitCoins(index uint16) *struct {
+	Argument  Argument
+	Arguments []Argument
+} {
+	if int(index) >= len(s.pt.Commands) {
+		return nil
+	}
+	return s.pt.Commands[index].SplitCoins
+}
+
+func formatSui(mist uint64) string {
+	return decimal.NewFromBigInt(new(big.Int).SetUint64(mist), -SuiDecimals).String()
+}
@@ -0,0 +1,47 @@
+package sui_types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeTransaction(t *testing.T) {
+	sender, err := NewAddressFromHex("0x7e875ea78ee09f08d72e2676cf84e0f1c8ac61d94fa339cc8e37cace85bebc6e")
+	require.NoError(t, err)
+	recipient, err := NewAddressFromHex("0x123456")
+	require.NoError(t, err)
+
+	ptb := NewProgrammableTransactionBuilder()
+	err = ptb.PaySui([]SuiAddress{*recipient}, []uint64{5e9})
+	require.NoError(t, err)
+	err = ptb.MoveCall(*SuiSystemAddress, "sui_system", "request_add_stake", nil, nil)
+	require.NoError(t, err)
+	tx := NewProgrammable(*sender, nil, ptb.Finish(), 10000000, 1000)
+
+	require.Equal(
+		t, []string{
+			"sender " + sender.String(),
+			"gas budget 0.01 SUI at price 1000",
+			"split gas coin into 5 SUI",
+			"transfer 5 SUI to " + recipient.String(),
+			"call 0x3::sui_system::request_add_stake",
+		}, SummarizeTransaction(tx),
+	)
+
+	// an unknown input variant is not decoded
+	input := uint16(0)
+	unknown := ProgrammableTransaction{
+		Inputs: []CallArg{{}},
+		Commands: []Command{
+			{
+				TransferObjects: &struct {
+					Arguments []Argument
+					Argument  Argument
+				}{Arguments: []Argument{{Input: &input}}, Argument: Argument{Input: &input}},
+			},
+		},
+	}
+	summary := SummarizeTransaction(NewProgrammable(*sender, nil, unknown, 10000000, 1000))
+	require.Contains(t, summary[len(summary)-1], "input 0")
+}
@@ -1,8 +1,15 @@
 package sui_types
 
+// SuiDecimals the decimals of SUI, 1 SUI is 10^9 MIST
+const SuiDecimals = 9
+
 var (
+	SuiFrameworkPackageId, _          = NewObjectIdFromHex("0x2")
 	SuiSystemAddress, _               = NewAddressFromHex("0x3")
 	SuiSystemPackageId                = SuiSystemAddress
 	SuiSystemStateObjectId, _         = NewObjectIdFromHex("0x5")
 	SuiSystemStateObjectSharedVersion = ObjectStartVersion
+	SuiClockObjectId, _               = NewObjectIdFromHex("0x6")
+	SuiClockObjectSharedVersion       = ObjectStartVersion
+	SuiRandomObjectId, _              = NewObjectIdFromHex("0x8")
 )
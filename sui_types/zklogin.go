@@ -0,0 +1,141 @@
+package sui_types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/coming-chat/go-sui/v2/crypto"
+)
+
+const (
+	ZkLoginSignatureFlag byte = 0x05
+
+	ZkLoginMaxKeyClaimNameLength  = 32
+	ZkLoginMaxKeyClaimValueLength = 115
+	ZkLoginMaxAudLength           = 145
+
+	// zkLoginPackWidth the bits of a field element packed from an ascii string
+	zkLoginPackWidth = 248
+)
+
+// ZkLoginAddress computes the zkLogin address of the OAuth JWT and the user salt(a decimal string), which is
+// blake2b(flag || len(iss) || iss || address seed), the JWT claims used are:
+//   - iss: the issuer, `accounts.google.com` is normalized to `https://accounts.google.com`
+//   - aud: the client id of the app, which must be a single audience
+//   - sub: the subject, used as the key claim as the wallets do
+//
+// The signature of the JWT is not verified, the address only depends on the claims.
+func ZkLoginAddress(jwt string, salt string) (*SuiAddress, error) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("jwt should have 3 parts")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt payload: %w", err)
+	}
+	var claims struct {
+		Iss string          `json:"iss"`
+		Aud json.RawMessage `json:"aud"`
+		Sub string          `json:"sub"`
+	}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt claims: %w", err)
+	}
+	aud, err := singleJwtAudience(claims.Aud)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Iss == "" || claims.Sub == "" {
+		return nil, errors.New("jwt should have iss and sub claims")
+	}
+	saltInt, ok := new(big.Int).SetString(salt, 10)
+	if !ok {
+		return nil, errors.New("salt should be a decimal integer")
+	}
+	seed, err := ZkLoginAddressSeed("sub", claims.Sub, aud, saltInt)
+	if err != nil {
+		return nil, err
+	}
+	address := ZkLoginAddressFromSeed(seed, claims.Iss)
+	return &address, nil
+}
+
+// ZkLoginAddressSeed poseidon(hash(claimName), hash(claimValue), hash(aud), poseidon(salt))
+func ZkLoginAddressSeed(claimName, claimValue, aud string, salt *big.Int) (*big.Int, error) {
+	nameHash, err := hashASCIIStrToField(claimName, ZkLoginMaxKeyClaimNameLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim name: %w", err)
+	}
+	valueHash, err := hashASCIIStrToField(claimValue, ZkLoginMaxKeyClaimValueLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim value: %w", err)
+	}
+	audHash, err := hashASCIIStrToField(aud, ZkLoginMaxAudLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aud: %w", err)
+	}
+	saltHash, err := crypto.PoseidonHash([]*big.Int{salt})
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	return crypto.PoseidonHash([]*big.Int{nameHash, valueHash, audHash, saltHash})
+}
+
+// ZkLoginAddressFromSeed the seed is encoded as big endian bytes without the leading zeros,
+// which is the encoding of the addresses of the existing zkLogin accounts
+func ZkLoginAddressFromSeed(seed *big.Int, iss string) SuiAddress {
+	if iss == "accounts.google.com" {
+		iss = "https://accounts.google.com"
+	}
+	seedBytes := seed.Bytes()
+	if len(seedBytes) == 0 {
+		seedBytes = []byte{0}
+	}
+	data := make([]byte, 0, 2+len(iss)+len(seedBytes))
+	data = append(data, ZkLoginSignatureFlag, byte(len(iss)))
+	data = append(data, iss...)
+	data = append(data, seedBytes...)
+	return hash256(data)
+}
+
+func singleJwtAudience(raw json.RawMessage) (string, error) {
+	var aud string
+	if err := json.Unmarshal(raw, &aud); err == nil && aud != "" {
+		return aud, nil
+	}
+	var auds []string
+	if err := json.Unmarshal(raw, &auds); err == nil && len(auds) == 1 {
+		return auds[0], nil
+	}
+	return "", errors.New("jwt should have a single aud claim")
+}
+
+// hashASCIIStrToField pads str with zeros to maxSize, packs every 31 bytes into a field element and hashes them
+func hashASCIIStrToField(str string, maxSize int) (*big.Int, error) {
+	if len(str) > maxSize {
+		return nil, fmt.Errorf("string %v is longer than %d", str, maxSize)
+	}
+	padded := make([]byte, maxSize)
+	for i := 0; i < len(str); i++ {
+		if str[i] >= 0x80 {
+			return nil, fmt.Errorf("string %v is not ascii", str)
+		}
+		padded[i] = str[i]
+	}
+	chunkSize := zkLoginPackWidth / 8
+	var packed []*big.Int
+	for end := len(padded); end > 0; end -= chunkSize {
+		start := end - chunkSize
+		if start < 0 {
+			start = 0
+		}
+		packed = append([]*big.Int{new(big.Int).SetBytes(padded[start:end])}, packed...)
+	}
+	return crypto.PoseidonHash(packed)
+}
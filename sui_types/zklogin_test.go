@@ -0,0 +1,62 @@
+package sui_types
+
+import (
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZkLoginAddress(t *testing.T) {
+	jwtWithClaims := func(claims string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + "."
+	}
+	salt := "248191903847969014646285995941615069143"
+	jwt := jwtWithClaims(`{"sub":"8c2d7d66-87af-41fa-b6fb-63d4eca281fa","aud":"test","iss":"https://oauth.sui.io"}`)
+	address, err := ZkLoginAddress(jwt, salt)
+	require.NoError(t, err)
+	require.Equal(t, "0xae48b52846a7b8776540fa91f87715529e4d7cd65039e2ce21196c0a2d06e8d9", address.String())
+
+	// the address seed of the salt 83 is only 31 bytes, the leading zero is not hashed
+	seed, err := ZkLoginAddressSeed("sub", "8c2d7d66-87af-41fa-b6fb-63d4eca281fa", "test", big.NewInt(83))
+	require.NoError(t, err)
+	require.Len(t, seed.Bytes(), 31)
+	other, err := ZkLoginAddress(jwt, "83")
+	require.NoError(t, err)
+	require.Equal(t, "0xa7b98a33105c1c53bf3111074de43da61cd17dd6d1164897bf30eef18f47ef64", other.String())
+
+	// a single aud in an array is the same
+	other, err = ZkLoginAddress(
+		jwtWithClaims(`{"sub":"8c2d7d66-87af-41fa-b6fb-63d4eca281fa","aud":["test"],"iss":"https://oauth.sui.io"}`), salt,
+	)
+	require.NoError(t, err)
+	require.Equal(t, address, other)
+	other, err = ZkLoginAddress(jwt, "1")
+	require.NoError(t, err)
+	require.NotEqual(t, address, other)
+
+	google, err := ZkLoginAddress(jwtWithClaims(`{"sub":"1","aud":"test","iss":"accounts.google.com"}`), salt)
+	require.NoError(t, err)
+	googleHttps, err := ZkLoginAddress(jwtWithClaims(`{"sub":"1","aud":"test","iss":"https://accounts.google.com"}`), salt)
+	require.NoError(t, err)
+	require.Equal(t, google, googleHttps)
+
+	_, err = ZkLoginAddress(jwtWithClaims(`{"sub":"1","aud":["a","b"],"iss":"https://oauth.sui.io"}`), salt)
+	require.Error(t, err)
+	_, err = ZkLoginAddress(jwtWithClaims(`{"aud":"test","iss":"https://oauth.sui.io"}`), salt)
+	require.Error(t, err)
+	_, err = ZkLoginAddress(jwt, "0x1")
+	require.Error(t, err)
+	_, err = ZkLoginAddress("invalid", salt)
+	require.Error(t, err)
+}
+
+func TestHashASCIIStrToField(t *testing.T) {
+	_, err := hashASCIIStrToField("sub", ZkLoginMaxKeyClaimNameLength)
+	require.NoError(t, err)
+	_, err = hashASCIIStrToField(string(make([]byte, ZkLoginMaxKeyClaimNameLength+1)), ZkLoginMaxKeyClaimNameLength)
+	require.Error(t, err)
+	_, err = hashASCIIStrToField("ü", ZkLoginMaxKeyClaimNameLength)
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+)
+
+type Checkpoint struct {
+	// Checkpoint's epoch ID
+	Epoch SafeSuiBigInt[EpochId] `json:"epoch"`
+	// Checkpoint sequence number
+	SequenceNumber SafeSuiBigInt[CheckpointSequenceNumber] `json:"sequenceNumber"`
+	// Checkpoint digest
+	Digest sui_types.CheckpointDigest `json:"digest"`
+	// Total number of transactions committed since genesis, including those in this checkpoint.
+	NetworkTotalTransactions SafeSuiBigInt[uint64] `json:"networkTotalTransactions"`
+	// Digest of the previous checkpoint, nil for the genesis checkpoint
+	PreviousDigest *sui_types.CheckpointDigest `json:"previousDigest,omitempty"`
+	// The running total gas costs of all transactions included in the current epoch so far until this checkpoint.
+	EpochRollingGasCostSummary GasCostSummary `json:"epochRollingGasCostSummary"`
+	// Timestamp of the checkpoint - number of milliseconds from the Unix epoch
+	// Checkpoint timestamps are monotonic, but not strongly monotonic - subsequent
+	// checkpoints can have same timestamp if they originate from the same underlining consensus commit
+	TimestampMs SafeSuiBigInt[uint64] `json:"timestampMs"`
+	// Present only on the final checkpoint of the epoch.
+	EndOfEpochData *EndOfEpochData `json:"endOfEpochData,omitempty"`
+	// Transaction digests in the order they were executed
+	Transactions []sui_types.TransactionDigest `json:"transactions"`
+	// Commitments to checkpoint state
+	CheckpointCommitments []interface{} `json:"checkpointCommitments"`
+	// Validator Signature, the aggregated BLS signature of the committee
+	ValidatorSignature lib.Base64Data `json:"validatorSignature"`
+}
+
+type EndOfEpochData struct {
+	// next_epoch_committee is `Some` if and only if the current checkpoint is the last checkpoint of an epoch.
+	// each element is a tuple of the authority name and its voting power
+	NextEpochCommittee [][]interface{} `json:"nextEpochCommittee"`
+	// The protocol version that is in effect during the epoch that starts immediately after this checkpoint.
+	NextEpochProtocolVersion SafeSuiBigInt[uint64] `json:"nextEpochProtocolVersion"`
+	// Commitments to epoch specific state (e.g. live object set)
+	EpochCommitments []interface{} `json:"epochCommitments"`
+}
+
+type CheckpointPage = Page[Checkpoint, string]
+
+// ValidateCheckpointChain checks that the checkpoints are consecutive, and the previousDigest of each checkpoint
+// matches the digest of the prior one.
+// @return the sequence number of the first checkpoint that breaks the continuity
+// @throw ErrCheckpointChainBroken If a gap or a digest mismatch is found
+func ValidateCheckpointChain(checkpoints []Checkpoint) (CheckpointSequenceNumber, error) {
+	for i := 1; i < len(checkpoints); i++ {
+		prev, cur := &checkpoints[i-1], &checkpoints[i]
+		seq := cur.SequenceNumber.Uint64()
+		if seq != prev.SequenceNumber.Uint64()+1 {
+			return seq, fmt.Errorf(
+				"%w: checkpoint %d follows checkpoint %d", ErrCheckpointChainBroken, seq, prev.SequenceNumber.Uint64(),
+			)
+		}
+		if cur.PreviousDigest == nil || !bytes.Equal(cur.PreviousDigest.Data(), prev.Digest.Data()) {
+			return seq, fmt.Errorf(
+				"%w: previous digest of checkpoint %d does not match digest %v", ErrCheckpointChainBroken, seq,
+				prev.Digest,
+			)
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCheckpointChain(t *testing.T) {
+	digest := func(b byte) *sui_types.CheckpointDigest {
+		d := sui_types.CheckpointDigest{b}
+		return &d
+	}
+	checkpoint := func(seq uint64, prev byte) Checkpoint {
+		return Checkpoint{
+			SequenceNumber: NewSafeSuiBigInt(seq),
+			Digest:         *digest(byte(seq)),
+			PreviousDigest: digest(prev),
+		}
+	}
+
+	seq, err := ValidateCheckpointChain([]Checkpoint{checkpoint(10, 9), checkpoint(11, 10), checkpoint(12, 11)})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), seq)
+
+	seq, err = ValidateCheckpointChain([]Checkpoint{checkpoint(10, 9), checkpoint(11, 10), checkpoint(12, 99)})
+	require.ErrorIs(t, err, ErrCheckpointChainBroken)
+	require.Equal(t, uint64(12), seq)
+
+	seq, err = ValidateCheckpointChain([]Checkpoint{checkpoint(10, 9), checkpoint(12, 11)})
+	require.ErrorIs(t, err, ErrCheckpointChainBroken)
+	require.Equal(t, uint64(12), seq)
+}
@@ -1,10 +1,13 @@
 package types
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 	"sort"
 
+	"github.com/coming-chat/go-sui/v2/move_types"
 	"github.com/coming-chat/go-sui/v2/sui_types"
 )
 
@@ -29,6 +32,28 @@ type Coin struct {
 	PreviousTransaction sui_types.TransactionDigest `json:"previousTransaction"`
 }
 
+// NormalizeCoinType formats the coin type in the canonical form of the SDK, which is the format of the node with
+// the short addresses, e.g. `0x2::coin::Coin<0x2::sui::SUI>` for
+// `0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x2::sui::SUI>`.
+// The coin types of Coin, Balance and BalanceChange are normalized when decoded, so they can be compared as strings
+// with the normalized types. The invalid coin type is returned as is.
+func NormalizeCoinType(coinType string) string {
+	tag, err := move_types.ParseStructTag(coinType)
+	if err != nil {
+		return coinType
+	}
+	return tag.String()
+}
+
+func (c *Coin) UnmarshalJSON(data []byte) error {
+	type coin Coin
+	if err := json.Unmarshal(data, (*coin)(c)); err != nil {
+		return err
+	}
+	c.CoinType = NormalizeCoinType(c.CoinType)
+	return nil
+}
+
 func (c *Coin) Reference() *sui_types.ObjectRef {
 	return &sui_types.ObjectRef{
 		Digest:   c.Digest,
@@ -50,6 +75,15 @@ type Balance struct {
 	LockedBalance   map[SafeSuiBigInt[uint64]]SuiBigInt `json:"lockedBalance"`
 }
 
+func (b *Balance) UnmarshalJSON(data []byte) error {
+	type balance Balance
+	if err := json.Unmarshal(data, (*balance)(b)); err != nil {
+		return err
+	}
+	b.CoinType = NormalizeCoinType(b.CoinType)
+	return nil
+}
+
 type Supply struct {
 	Value SafeSuiBigInt[uint64] `json:"value"`
 }
@@ -174,12 +208,129 @@ func (cs Coins) PickCoinNoLess(amount uint64) (*Coin, error) {
 	return nil, errors.New("no coin is enough to cover the gas")
 }
 
+// FindDustCoins returns the coins whose balance is less than threshold, in the order of coins.
+// The dust coins are not worth spending individually, they can be merged into one coin by MergeCoins,
+// or by PayAllSui for the SUI coins
+func FindDustCoins(coins []Coin, threshold SuiBigInt) []Coin {
+	var dust []Coin
+	for _, coin := range coins {
+		if coin.Balance.Decimal().LessThan(threshold) {
+			dust = append(dust, coin)
+		}
+	}
+	return dust
+}
+
 const (
 	PickSmaller = iota // pick smaller coins to match amount
 	PickBigger         // pick bigger coins to match amount
 	PickByOrder        // pick coins by coins order to match amount
 )
 
+// CoinSelector selects the coins whose total balance covers the amount
+type CoinSelector interface {
+	SelectCoins(coins Coins, amount *big.Int) (Coins, error)
+}
+
+// FewestInputsSelector selects the biggest coins first, so the amount is covered by the fewest coins,
+// which makes the transaction smaller and cheaper. It is the default selector of SelectCoinsForPaymentAndGas
+type FewestInputsSelector struct{}
+
+func (FewestInputsSelector) SelectCoins(coins Coins, amount *big.Int) (Coins, error) {
+	return coins.PickCoins(amount, PickBigger)
+}
+
+// SmallestFirstSelector selects the smallest coins first, which spends and consolidates the dust coins,
+// at the cost of more inputs than FewestInputsSelector
+type SmallestFirstSelector struct{}
+
+func (SmallestFirstSelector) SelectCoins(coins Coins, amount *big.Int) (Coins, error) {
+	return coins.PickCoins(amount, PickSmaller)
+}
+
+type SelectCoinsOption func(*selectCoinsOptions)
+
+type selectCoinsOptions struct {
+	selector CoinSelector
+}
+
+// WithCoinSelector selects the coins of SelectCoinsForPaymentAndGas by selector, FewestInputsSelector if nil
+func WithCoinSelector(selector CoinSelector) SelectCoinsOption {
+	return func(o *selectCoinsOptions) {
+		o.selector = selector
+	}
+}
+
+// PaymentAndGasCoins the disjoint coins selected by SelectCoinsForPaymentAndGas
+type PaymentAndGasCoins struct {
+	// Payment the coins to merge and split the amount from, empty if SplitFromGas
+	Payment Coins
+	// Gas the gas payment, which are SUI coins
+	Gas Coins
+	// SplitFromGas the amount and the gas are both SUI, the amount should be split from the GasCoin argument,
+	// which is the gas coins merged by the node, so no SUI coin is used twice
+	SplitFromGas bool
+}
+
+// SelectCoinsForPaymentAndGas selects the coins to pay the amount and the gas budget, by FewestInputsSelector
+// unless WithCoinSelector is given.
+// The coins should be the coins of one type to pay with, and the SUI coins for the gas, the types are compared by
+// NormalizeCoinType:
+//   - if all the coins are SUI, the coins covering amount + gasBudget are the gas payment, and SplitFromGas is set
+//   - otherwise the payment coins covering the amount are selected from the other type, and the gas coins covering
+//     gasBudget are selected from the SUI coins
+//
+// ErrCoinsNeedMoreObject is returned if the coins are not enough, ErrNeedMergeCoin if the gas needs more coins than
+// the max number of the gas payment objects
+func SelectCoinsForPaymentAndGas(
+	coins []Coin,
+	amount, gasBudget uint64,
+	opts ...SelectCoinsOption,
+) (*PaymentAndGasCoins, error) {
+	var options selectCoinsOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	selector := options.selector
+	if selector == nil {
+		selector = FewestInputsSelector{}
+	}
+	var suiCoins, payCoins Coins
+	for _, coin := range coins {
+		coinType := NormalizeCoinType(coin.CoinType)
+		if coinType == SUI_COIN_TYPE {
+			suiCoins = append(suiCoins, coin)
+			continue
+		}
+		if len(payCoins) > 0 && NormalizeCoinType(payCoins[0].CoinType) != coinType {
+			return nil, fmt.Errorf("coins of more than one type: %v and %v", payCoins[0].CoinType, coin.CoinType)
+		}
+		payCoins = append(payCoins, coin)
+	}
+
+	result := &PaymentAndGasCoins{}
+	gasAmount := new(big.Int).SetUint64(gasBudget)
+	if len(payCoins) == 0 {
+		result.SplitFromGas = true
+		gasAmount.Add(gasAmount, new(big.Int).SetUint64(amount))
+	} else {
+		payment, err := selector.SelectCoins(payCoins, new(big.Int).SetUint64(amount))
+		if err != nil {
+			return nil, err
+		}
+		result.Payment = payment
+	}
+	gas, err := selector.SelectCoins(suiCoins, gasAmount)
+	if err != nil {
+		return nil, err
+	}
+	if len(gas) > MAX_INPUT_COUNT_MERGE {
+		return nil, ErrNeedMergeCoin
+	}
+	result.Gas = gas
+	return result, nil
+}
+
 // PickSUICoinsWithGas pick coins, which sum >= amount, and pick a gas coin >= gasAmount which not in coins
 // if not satisfated amount/gasAmount, an ErrCoinsNotMatchRequest/ErrCoinsNeedMoreObject error will return
 // if gasAmount == 0, a nil gasCoin will return
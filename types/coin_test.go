@@ -1,11 +1,13 @@
 package types
 
 import (
+	"encoding/json"
 	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -367,4 +369,138 @@ func TestPickupCoins(t *testing.T) {
 			},
 		)
 	}
-}
\ No newline at end of file
+}
+
+func TestNormalizeCoinType(t *testing.T) {
+	full := "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI"
+	require.Equal(t, SUI_COIN_TYPE, NormalizeCoinType(full))
+	require.Equal(t, SUI_COIN_TYPE, NormalizeCoinType(SUI_COIN_TYPE))
+	require.Equal(
+		t, "0x2::coin::Coin<0xab::usdc::USDC>",
+		NormalizeCoinType("0x2::coin::Coin<0x00000000000000000000000000000000000000000000000000000000000000ab::usdc::USDC>"),
+	)
+	require.Equal(t, "not a type", NormalizeCoinType("not a type"))
+
+	var coin Coin
+	require.NoError(t, json.Unmarshal([]byte(`{"coinType":"`+full+`","balance":"1","version":"1"}`), &coin))
+	require.True(t, coin.IsSUI())
+	var balance Balance
+	require.NoError(t, json.Unmarshal([]byte(`{"coinType":"`+full+`","totalBalance":"1"}`), &balance))
+	require.Equal(t, SUI_COIN_TYPE, balance.CoinType)
+	var change BalanceChange
+	require.NoError(t, json.Unmarshal([]byte(`{"owner":{"AddressOwner":"0x1"},"coinType":"`+full+`","amount":"-1"}`), &change))
+	require.Equal(t, SUI_COIN_TYPE, change.CoinType)
+}
+
+func TestFindDustCoins(t *testing.T) {
+	coins := []Coin{
+		{Balance: balanceObject(5)},
+		{Balance: balanceObject(1000)},
+		{Balance: balanceObject(999)},
+		{Balance: balanceObject(18446744073709551615)},
+	}
+	dust := FindDustCoins(coins, decimal.NewFromInt(1000))
+	require.Equal(t, []Coin{coins[0], coins[2]}, dust)
+	require.Empty(t, FindDustCoins(coins, decimal.NewFromInt(5)))
+	require.Len(t, FindDustCoins(Coins(coins), decimal.RequireFromString("18446744073709551616")), 4)
+}
+
+func TestSelectCoinsForPaymentAndGas(t *testing.T) {
+	suiCoin := func(id byte, balance uint64) Coin {
+		return Coin{CoinType: SUI_COIN_TYPE, CoinObjectId: sui_types.ObjectID{id}, Balance: balanceObject(balance)}
+	}
+	usdcCoin := func(id byte, balance uint64) Coin {
+		return Coin{CoinType: "0xab::usdc::USDC", CoinObjectId: sui_types.ObjectID{id}, Balance: balanceObject(balance)}
+	}
+
+	// SUI pays both the amount and the gas from the gas coins
+	selected, err := SelectCoinsForPaymentAndGas([]Coin{suiCoin(1, 100), suiCoin(2, 800), suiCoin(3, 300)}, 900, 100)
+	require.NoError(t, err)
+	require.True(t, selected.SplitFromGas)
+	require.Empty(t, selected.Payment)
+	require.Equal(t, Coins{suiCoin(2, 800), suiCoin(3, 300)}, selected.Gas)
+
+	_, err = SelectCoinsForPaymentAndGas([]Coin{suiCoin(1, 100), suiCoin(2, 800)}, 900, 100)
+	require.ErrorIs(t, err, ErrCoinsNeedMoreObject)
+
+	// the other coin type pays the amount, SUI pays the gas
+	selected, err = SelectCoinsForPaymentAndGas(
+		[]Coin{usdcCoin(4, 50), suiCoin(1, 100), usdcCoin(5, 70), usdcCoin(6, 10), suiCoin(2, 800)}, 100, 500,
+	)
+	require.NoError(t, err)
+	require.False(t, selected.SplitFromGas)
+	require.Equal(t, Coins{usdcCoin(5, 70), usdcCoin(4, 50)}, selected.Payment)
+	require.Equal(t, Coins{suiCoin(2, 800)}, selected.Gas)
+
+	// the dust is spent first by SmallestFirstSelector, nil is the default
+	mixed := []Coin{usdcCoin(4, 50), suiCoin(1, 600), usdcCoin(5, 70), usdcCoin(6, 10), suiCoin(2, 800)}
+	selected, err = SelectCoinsForPaymentAndGas(mixed, 100, 500, WithCoinSelector(SmallestFirstSelector{}))
+	require.NoError(t, err)
+	require.Equal(t, Coins{usdcCoin(6, 10), usdcCoin(4, 50), usdcCoin(5, 70)}, selected.Payment)
+	require.Equal(t, Coins{suiCoin(1, 600)}, selected.Gas)
+	selected, err = SelectCoinsForPaymentAndGas(mixed, 100, 500, WithCoinSelector(nil))
+	require.NoError(t, err)
+	require.Equal(t, Coins{usdcCoin(5, 70), usdcCoin(4, 50)}, selected.Payment)
+	require.Equal(t, Coins{suiCoin(2, 800)}, selected.Gas)
+
+	_, err = SelectCoinsForPaymentAndGas([]Coin{usdcCoin(4, 50), suiCoin(1, 100)}, 10, 500)
+	require.ErrorIs(t, err, ErrCoinsNeedMoreObject)
+
+	// the coin types in the padded form are the same types
+	paddedUsdc := usdcCoin(7, 100)
+	paddedUsdc.CoinType = "0x00000000000000000000000000000000000000000000000000000000000000ab::usdc::USDC"
+	paddedSui := suiCoin(3, 600)
+	paddedSui.CoinType = "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI"
+	selected, err = SelectCoinsForPaymentAndGas([]Coin{usdcCoin(4, 50), paddedUsdc, paddedSui}, 120, 500)
+	require.NoError(t, err)
+	require.Equal(t, Coins{paddedUsdc, usdcCoin(4, 50)}, selected.Payment)
+	require.Equal(t, Coins{paddedSui}, selected.Gas)
+	_, err = SelectCoinsForPaymentAndGas(
+		[]Coin{usdcCoin(4, 50), {CoinType: "0xab::usdt::USDT", Balance: balanceObject(50)}, suiCoin(1, 100)}, 10, 50,
+	)
+	require.Error(t, err)
+}
+
+func TestCoinSelector(t *testing.T) {
+	coins := Coins{{Balance: balanceObject(5)}, {Balance: balanceObject(100)}, {Balance: balanceObject(20)}}
+	selected, err := FewestInputsSelector{}.SelectCoins(coins, big.NewInt(25))
+	require.NoError(t, err)
+	require.Equal(t, Coins{coins[1]}, selected)
+	selected, err = SmallestFirstSelector{}.SelectCoins(coins, big.NewInt(25))
+	require.NoError(t, err)
+	require.Equal(t, Coins{coins[0], coins[2]}, selected)
+	_, err = FewestInputsSelector{}.SelectCoins(coins, big.NewInt(126))
+	require.ErrorIs(t, err, ErrCoinsNeedMoreObject)
+}
+
+// BenchmarkCoinSelector reports the average number of the selected coins as inputs/op
+func BenchmarkCoinSelector(b *testing.B) {
+	coins := make(Coins, 500)
+	for i := range coins {
+		// a few big coins among the dust
+		balance := uint64(i%50 + 1)
+		if i%100 == 0 {
+			balance = 10000
+		}
+		coins[i] = Coin{Balance: balanceObject(balance)}
+	}
+	amount := big.NewInt(12000)
+	for name, selector := range map[string]CoinSelector{
+		"FewestInputs":  FewestInputsSelector{},
+		"SmallestFirst": SmallestFirstSelector{},
+	} {
+		b.Run(
+			name, func(b *testing.B) {
+				inputs := 0
+				for i := 0; i < b.N; i++ {
+					selected, err := selector.SelectCoins(coins, amount)
+					if err != nil {
+						b.Fatal(err)
+					}
+					inputs += len(selected)
+				}
+				b.ReportMetric(float64(inputs)/float64(b.N), "inputs/op")
+			},
+		)
+	}
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"math/big"
+	"strconv"
 
 	"github.com/coming-chat/go-sui/v2/sui_types"
 	"github.com/shopspring/decimal"
@@ -48,7 +49,28 @@ func (s *SafeSuiBigInt[T]) UnmarshalJSON(data []byte) error {
 }
 
 func (s SafeSuiBigInt[T]) MarshalJSON() ([]byte, error) {
-	return decimal.NewFromInt(int64(s.data)).MarshalJSON()
+	return s.Decimal().MarshalJSON()
+}
+
+// String returns the decimal form, e.g. "18446744073709551615"
+func (s SafeSuiBigInt[T]) String() string {
+	if s.data < 0 {
+		return strconv.FormatInt(int64(s.data), 10)
+	}
+	return strconv.FormatUint(uint64(s.data), 10)
+}
+
+// Cmp returns -1 if s < other, 0 if s == other and +1 if s > other, which can be used to sort coins by balance.
+// SuiBigInt is a decimal.Decimal which has its own Cmp and String
+func (s SafeSuiBigInt[T]) Cmp(other SafeSuiBigInt[T]) int {
+	switch {
+	case s.data < other.data:
+		return -1
+	case s.data > other.data:
+		return 1
+	default:
+		return 0
+	}
 }
 
 func (s SafeSuiBigInt[T]) Int64() int64 {
@@ -59,7 +81,10 @@ func (s SafeSuiBigInt[T]) Uint64() uint64 {
 	return uint64(s.data)
 }
 
-func (s *SafeSuiBigInt[T]) Decimal() decimal.Decimal {
+func (s SafeSuiBigInt[T]) Decimal() decimal.Decimal {
+	if s.data < 0 {
+		return decimal.NewFromInt(int64(s.data))
+	}
 	return decimal.NewFromBigInt(big.NewInt(0).SetUint64(s.Uint64()), 0)
 }
 
@@ -83,9 +108,16 @@ type ObjectOwner struct {
 	*string
 }
 
-type Page[T SuiTransactionBlockResponse | SuiEvent | Coin | SuiObjectResponse | DynamicFieldInfo | string,
-	C sui_types.TransactionDigest | EventId | sui_types.ObjectID | string] struct {
+type PageData interface {
+	SuiTransactionBlockResponse | SuiEvent | Coin | SuiObjectResponse | DynamicFieldInfo | Checkpoint | EpochInfo | string
+}
+
+type PageCursor interface {
+	sui_types.TransactionDigest | EventId | sui_types.ObjectID | string
+}
+
+type Page[T PageData, C PageCursor] struct {
 	Data        []T  `json:"data"`
 	NextCursor  *C   `json:"nextCursor,omitempty"`
 	HasNextPage bool `json:"hasNextPage"`
-}
\ No newline at end of file
+}
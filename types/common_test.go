@@ -0,0 +1,53 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeSuiBigInt_CmpAndString(t *testing.T) {
+	max := NewSafeSuiBigInt(uint64(math.MaxUint64))
+	require.Equal(t, "18446744073709551615", max.String())
+	require.Equal(t, 1, max.Cmp(NewSafeSuiBigInt(uint64(math.MaxInt64)+1)))
+	require.Equal(t, -1, NewSafeSuiBigInt(uint64(0)).Cmp(max))
+	require.Equal(t, 0, max.Cmp(NewSafeSuiBigInt(uint64(math.MaxUint64))))
+
+	data, err := json.Marshal(max)
+	require.NoError(t, err)
+	require.Equal(t, `"18446744073709551615"`, string(data))
+	var decoded SafeSuiBigInt[uint64]
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, 0, max.Cmp(decoded))
+
+	negative := NewSafeSuiBigInt(int64(-5))
+	require.Equal(t, "-5", negative.String())
+	require.Equal(t, -1, negative.Cmp(NewSafeSuiBigInt(int64(3))))
+	data, err = json.Marshal(negative)
+	require.NoError(t, err)
+	require.Equal(t, `"-5"`, string(data))
+
+	coins := []Coin{
+		{Balance: NewSafeSuiBigInt(uint64(math.MaxUint64))},
+		{Balance: NewSafeSuiBigInt(uint64(1))},
+		{Balance: NewSafeSuiBigInt(uint64(math.MaxInt64) + 1)},
+	}
+	sort.Slice(coins, func(i, j int) bool { return coins[i].Balance.Cmp(coins[j].Balance) < 0 })
+	require.Equal(t, "1", coins[0].Balance.String())
+	require.Equal(t, "9223372036854775808", coins[1].Balance.String())
+	require.Equal(t, "18446744073709551615", coins[2].Balance.String())
+
+	// the total balance can be beyond u64
+	var total SuiBigInt
+	err = json.Unmarshal([]byte(`"36893488147419103230"`), &total)
+	require.NoError(t, err)
+	require.Equal(t, 1, total.Cmp(max.Decimal()))
+	require.True(t, total.Equal(max.Decimal().Add(max.Decimal())))
+	require.Equal(t, "36893488147419103230", total.String())
+	require.Equal(t, 0, decimal.Zero.Cmp(NewSafeSuiBigInt(uint64(0)).Decimal()))
+}
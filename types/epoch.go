@@ -0,0 +1,33 @@
+package types
+
+type EpochInfo struct {
+	// Epoch number
+	Epoch SafeSuiBigInt[EpochId] `json:"epoch"`
+	// List of validators included in epoch
+	Validators []SuiValidatorSummary `json:"validators"`
+	// Count of tx in epoch
+	EpochTotalTransactions SafeSuiBigInt[uint64] `json:"epochTotalTransactions"`
+	// First, last checkpoint sequence numbers
+	FirstCheckpointId SafeSuiBigInt[CheckpointSequenceNumber] `json:"firstCheckpointId"`
+	// The timestamp when the epoch started
+	EpochStartTimestamp SafeSuiBigInt[uint64] `json:"epochStartTimestamp"`
+	// End of epoch info, nil for the current epoch
+	EndOfEpochInfo *EndOfEpochInfo `json:"endOfEpochInfo,omitempty"`
+	// The reference gas price of the epoch, which may be nil if the indexer has not recorded it
+	ReferenceGasPrice *SafeSuiBigInt[uint64] `json:"referenceGasPrice,omitempty"`
+}
+
+type EndOfEpochInfo struct {
+	LastCheckpointId  SafeSuiBigInt[CheckpointSequenceNumber] `json:"lastCheckpointId"`
+	EpochEndTimestamp SafeSuiBigInt[uint64]                   `json:"epochEndTimestamp"`
+	// Protocol version of the next epoch
+	ProtocolVersion SafeSuiBigInt[uint64] `json:"protocolVersion"`
+	// The reference gas price of the next epoch
+	ReferenceGasPrice SafeSuiBigInt[uint64] `json:"referenceGasPrice"`
+	TotalStake        SafeSuiBigInt[uint64] `json:"totalStake"`
+	StorageCharge     SafeSuiBigInt[uint64] `json:"storageCharge"`
+	StorageRebate     SafeSuiBigInt[uint64] `json:"storageRebate"`
+	TotalGasFees      SafeSuiBigInt[uint64] `json:"totalGasFees"`
+}
+
+type EpochPage = Page[EpochInfo, string]
@@ -11,4 +11,7 @@ var (
 
 	ErrCoinsNotMatchRequest = errors.New("coins not match request")
 	ErrCoinsNeedMoreObject  = errors.New("you should get more SUI coins and try again")
+
+	ErrCheckpointChainBroken = errors.New("checkpoint chain is broken")
+	ErrSplitCoinAmbiguous    = errors.New("more than one created coin matches the split result")
 )
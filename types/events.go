@@ -1,6 +1,13 @@
 package types
 
-import "github.com/coming-chat/go-sui/v2/sui_types"
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+)
 
 type EventId struct {
 	TxDigest sui_types.TransactionDigest `json:"txDigest"`
@@ -17,13 +24,48 @@ type SuiEvent struct {
 	Sender sui_types.SuiAddress `json:"sender"`
 	// Move event type.
 	Type string `json:"type"`
+	// StructTag the parsed Type including the type params, e.g. `0x2::coin::CoinEvent<0x2::sui::SUI>`, so the events can
+	// be matched by the address, module and name regardless of the address padding. It is nil if Type is not a struct
+	StructTag *move_types.StructTag `json:"-"`
 	// Parsed json value of the event
 	ParsedJson interface{} `json:"parsedJson,omitempty"`
 	// Base 58 encoded bcs bytes of the move event
-	Bcs         string                 `json:"bcs"`
+	Bcs string `json:"bcs"`
+	// TimestampMs the timestamp of the checkpoint of the event, nil if the node doesn't return it,
+	// e.g. the events of a transaction not yet in a checkpoint
 	TimestampMs *SafeSuiBigInt[uint64] `json:"timestampMs,omitempty"`
 }
 
+func (e *SuiEvent) UnmarshalJSON(data []byte) error {
+	type event SuiEvent
+	if err := json.Unmarshal(data, (*event)(e)); err != nil {
+		return err
+	}
+	e.StructTag, _ = move_types.ParseStructTag(e.Type)
+	return nil
+}
+
+// Time returns the timestamp of the event, the zero time.Time if there is no timestamp
+func (e SuiEvent) Time() time.Time {
+	if e.TimestampMs == nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(e.TimestampMs.Uint64()))
+}
+
+// SortEventsByTime sorts the events by the timestamps in ascending order, the events without a timestamp are the last.
+// The sort is stable, so the events of the same timestamp keep their order, e.g. the order of the event seqs
+func SortEventsByTime(events []SuiEvent) {
+	sort.SliceStable(
+		events, func(i, j int) bool {
+			if events[i].TimestampMs == nil || events[j].TimestampMs == nil {
+				return events[i].TimestampMs != nil && events[j].TimestampMs == nil
+			}
+			return events[i].TimestampMs.Uint64() < events[j].TimestampMs.Uint64()
+		},
+	)
+}
+
 type EventFilter struct {
 	/// Query by sender sui_types.address.
 	Sender *sui_types.SuiAddress `json:"Sender,omitempty"`
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortEventsByTime(t *testing.T) {
+	var events []SuiEvent
+	err := json.Unmarshal(
+		[]byte(`[
+			{"id":{"txDigest":"11111111111111111111111111111111","eventSeq":"0"},"timestampMs":"1690000000002"},
+			{"id":{"txDigest":"11111111111111111111111111111111","eventSeq":"1"}},
+			{"id":{"txDigest":"11111111111111111111111111111111","eventSeq":"2"},"timestampMs":"1690000000001"},
+			{"id":{"txDigest":"11111111111111111111111111111111","eventSeq":"3"},"timestampMs":"1690000000001"},
+			{"id":{"txDigest":"11111111111111111111111111111111","eventSeq":"4"},"timestampMs":null}
+		]`), &events,
+	)
+	require.NoError(t, err)
+	require.Equal(t, time.UnixMilli(1690000000002), events[0].Time())
+	require.True(t, events[1].Time().IsZero())
+	require.True(t, events[4].Time().IsZero())
+
+	SortEventsByTime(events)
+	var seqs []uint64
+	for _, event := range events {
+		seqs = append(seqs, event.Id.EventSeq.Uint64())
+	}
+	require.Equal(t, []uint64{2, 3, 0, 1, 4}, seqs)
+}
+
+func TestSuiEvent_StructTag(t *testing.T) {
+	var events []SuiEvent
+	err := json.Unmarshal(
+		[]byte(`[
+			{"id":{"txDigest":"11111111111111111111111111111111","eventSeq":"0"},
+				"type":"0x0000000000000000000000000000000000000000000000000000000000000002::coin::CoinEvent<0x2::sui::SUI>"},
+			{"id":{"txDigest":"11111111111111111111111111111111","eventSeq":"1"},"type":"0x3::validator::StakingRequestEvent"},
+			{"id":{"txDigest":"11111111111111111111111111111111","eventSeq":"2"}}
+		]`), &events,
+	)
+	require.NoError(t, err)
+
+	tag := events[0].StructTag
+	require.NotNil(t, tag)
+	require.Equal(t, "0x2::coin::CoinEvent<0x2::sui::SUI>", tag.String())
+	require.Equal(t, move_types.Identifier("coin"), tag.Module)
+	require.Equal(t, move_types.Identifier("CoinEvent"), tag.Name)
+	require.Len(t, tag.TypeParams, 1)
+	require.Equal(t, "0x2::sui::SUI", tag.TypeParams[0].Struct.String())
+
+	require.Equal(t, "0x3::validator::StakingRequestEvent", events[1].StructTag.String())
+	require.Nil(t, events[2].StructTag)
+}
@@ -62,3 +62,15 @@ func (t *ResourceType) ShortString() string {
 		return fmt.Sprintf("%v::%v::%v", t.Address.ShortString(), t.ModuleName, t.FuncName)
 	}
 }
+
+type SuiMoveAbilitySet struct {
+	Abilities []string `json:"abilities"`
+}
+
+type SuiMoveNormalizedFunction struct {
+	Visibility     string              `json:"visibility"`
+	IsEntry        bool                `json:"isEntry"`
+	TypeParameters []SuiMoveAbilitySet `json:"typeParameters"`
+	Parameters     []interface{}       `json:"parameters"`
+	Return         []interface{}       `json:"return"`
+}
@@ -1,6 +1,11 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/coming-chat/go-sui/v2/lib"
 	"github.com/coming-chat/go-sui/v2/sui_types"
 )
@@ -14,6 +19,18 @@ type SuiObjectRef struct {
 	Version sui_types.SequenceNumber `json:"version"`
 }
 
+func (r SuiObjectRef) ObjectRef() (*sui_types.ObjectRef, error) {
+	objectId, err := sui_types.NewObjectIdFromHex(r.ObjectId)
+	if err != nil {
+		return nil, err
+	}
+	return &sui_types.ObjectRef{
+		ObjectId: *objectId,
+		Version:  r.Version,
+		Digest:   r.Digest,
+	}, nil
+}
+
 type SuiGasData struct {
 	Payment []SuiObjectRef `json:"payment"`
 	/** Gas Object's owner */
@@ -22,6 +39,11 @@ type SuiGasData struct {
 	Budget SafeSuiBigInt[uint64] `json:"budget"`
 }
 
+// OwnerAddress parses the owner of the gas objects, which is the address in TransactionData.V1.GasData
+func (g SuiGasData) OwnerAddress() (*sui_types.SuiAddress, error) {
+	return sui_types.NewAddressFromHex(g.Owner)
+}
+
 type SuiParsedData struct {
 	MoveObject *SuiParsedMoveObject `json:"moveObject,omitempty"`
 	Package    *SuiMovePackage      `json:"package,omitempty"`
@@ -45,6 +67,102 @@ type SuiParsedMoveObject struct {
 	Fields            any    `json:"fields"`
 }
 
+// DecodeFields decodes the untyped fields into v, which is usually a pointer to a struct with json tags.
+// The nested structs are decoded as nested Go structs, and the vectors of structs as slices,
+// the `type` of the nested structs is dropped, see normalizeMoveValue
+func (o SuiParsedMoveObject) DecodeFields(v any) error {
+	fieldsJson, err := json.Marshal(normalizeMoveValue(o.Fields))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(fieldsJson, v)
+}
+
+// normalizeMoveValue unwraps the nested structs of the node, which are {"type": ..., "fields": {...}}, to the fields,
+// and the options rendered as structs, which are {"vec": []} or {"vec": [value]}, to null or the value
+func normalizeMoveValue(value any) any {
+	switch v := value.(type) {
+	case []any:
+		values := make([]any, len(v))
+		for i, item := range v {
+			values[i] = normalizeMoveValue(item)
+		}
+		return values
+	case map[string]any:
+		typ, hasType := v["type"].(string)
+		fields, hasFields := v["fields"].(map[string]any)
+		if len(v) == 2 && hasType && hasFields {
+			if vec, ok := fields["vec"].([]any); ok && len(fields) == 1 && isMoveOptionType(typ) && len(vec) <= 1 {
+				if len(vec) == 0 {
+					return nil
+				}
+				return normalizeMoveValue(vec[0])
+			}
+			return normalizeMoveValue(fields)
+		}
+		values := make(map[string]any, len(v))
+		for name, item := range v {
+			values[name] = normalizeMoveValue(item)
+		}
+		return values
+	default:
+		return value
+	}
+}
+
+// isMoveOptionType the address of the type may be short or full length
+func isMoveOptionType(typ string) bool {
+	return strings.HasPrefix(typ, "0x1::option::Option<") ||
+		strings.HasPrefix(typ, "0x0000000000000000000000000000000000000000000000000000000000000001::option::Option<")
+}
+
+// DecodeField decodes the field `name` into v as DecodeFields does
+func (o SuiParsedMoveObject) DecodeField(name string, v any) error {
+	fields, ok := o.Fields.(map[string]any)
+	if !ok {
+		return errors.New("fields of the move object is not a struct")
+	}
+	value, ok := fields[name]
+	if !ok {
+		return fmt.Errorf("move object has no field %v", name)
+	}
+	valueJson, err := json.Marshal(normalizeMoveValue(value))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(valueJson, v)
+}
+
+// AddressField decodes the `address` field `name` of the object
+func (o SuiParsedMoveObject) AddressField(name string) (*sui_types.SuiAddress, error) {
+	fields, ok := o.Fields.(map[string]any)
+	if !ok {
+		return nil, errors.New("fields of the move object is not a struct")
+	}
+	value, ok := fields[name]
+	if !ok {
+		return nil, fmt.Errorf("move object has no field %v", name)
+	}
+	addr, err := DecodeAddressField(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field %v: %w", name, err)
+	}
+	return addr, nil
+}
+
+// DecodeAddressField decodes an `address` value of the parsed fields, which is a 0x string in the JSON,
+// the address is validated and normalized by sui_types.NewAddressFromHex
+func DecodeAddressField(value any) (*sui_types.SuiAddress, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("address should be a string, got %T", value)
+	}
+	if !strings.HasPrefix(str, "0x") && !strings.HasPrefix(str, "0X") {
+		return nil, fmt.Errorf("address should start with 0x, got %v", str)
+	}
+	return sui_types.NewAddressFromHex(str)
+}
+
 type SuiRawData struct {
 	MoveObject *SuiRawMoveObject  `json:"moveObject,omitempty"`
 	Package    *SuiRawMovePackage `json:"package,omitempty"`
@@ -65,6 +183,20 @@ type SuiRawMoveObject struct {
 	BcsBytes          lib.Base64Data           `json:"bcsBytes"`
 }
 
+// DecodeBcs decodes the BCS bytes of the object into v, which is a pointer to a struct of the same layout as
+// the move struct, e.g. sui_types.Coin for `0x2::coin::Coin<T>`. All the bytes must be consumed,
+// so a struct with a different layout is rejected instead of decoded partially
+func (o SuiRawMoveObject) DecodeBcs(v any) error {
+	n, err := lib.UnmarshalBcs(o.BcsBytes.Data(), v)
+	if err != nil {
+		return err
+	}
+	if n != len(o.BcsBytes.Data()) {
+		return fmt.Errorf("%d trailing bytes after decoding %v", len(o.BcsBytes.Data())-n, o.Type)
+	}
+	return nil
+}
+
 type SuiRawMovePackage struct {
 	Id              sui_types.ObjectID        `json:"id"`
 	Version         sui_types.SequenceNumber  `json:"version"`
@@ -187,11 +319,30 @@ type ObjectsPage = Page[SuiObjectResponse, sui_types.ObjectID]
 
 // TODO need use Enum
 type SuiObjectDataFilter struct {
+	// MatchAll the objects matching all the filters
+	MatchAll []SuiObjectDataFilter `json:"MatchAll,omitempty"`
+	// MatchAny the objects matching any of the filters
+	MatchAny []SuiObjectDataFilter `json:"MatchAny,omitempty"`
+	// MatchNone the objects matching none of the filters, e.g. excluding the coins
+	MatchNone []SuiObjectDataFilter `json:"MatchNone,omitempty"`
+
 	Package    *sui_types.ObjectID `json:"Package,omitempty"`
 	MoveModule *MoveModule         `json:"MoveModule,omitempty"`
 	StructType string              `json:"StructType,omitempty"`
 }
 
+func NewObjectFilterMatchAll(filters ...SuiObjectDataFilter) *SuiObjectDataFilter {
+	return &SuiObjectDataFilter{MatchAll: filters}
+}
+
+func NewObjectFilterMatchAny(filters ...SuiObjectDataFilter) *SuiObjectDataFilter {
+	return &SuiObjectDataFilter{MatchAny: filters}
+}
+
+func NewObjectFilterMatchNone(filters ...SuiObjectDataFilter) *SuiObjectDataFilter {
+	return &SuiObjectDataFilter{MatchNone: filters}
+}
+
 type SuiObjectResponseQuery struct {
 	Filter  *SuiObjectDataFilter  `json:"filter,omitempty"`
 	Options *SuiObjectDataOptions `json:"options,omitempty"`
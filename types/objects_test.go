@@ -0,0 +1,190 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/fardream/go-bcs/bcs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuiParsedMoveObject_AddressField(t *testing.T) {
+	var object SuiParsedMoveObject
+	err := json.Unmarshal(
+		[]byte(`{"type":"0x2::kiosk::Kiosk","hasPublicTransfer":false,"fields":{"owner":"0xa","profits":"0","count":1}}`),
+		&object,
+	)
+	require.NoError(t, err)
+
+	owner, err := object.AddressField("owner")
+	require.NoError(t, err)
+	want, err := sui_types.NewAddressFromHex("0x000000000000000000000000000000000000000000000000000000000000000a")
+	require.NoError(t, err)
+	require.Equal(t, want, owner)
+
+	_, err = object.AddressField("count")
+	require.Error(t, err)
+	_, err = object.AddressField("profits")
+	require.Error(t, err)
+	_, err = object.AddressField("missing")
+	require.Error(t, err)
+
+	var fields struct {
+		Owner sui_types.SuiAddress `json:"owner"`
+	}
+	err = object.DecodeFields(&fields)
+	require.NoError(t, err)
+	require.Equal(t, *want, fields.Owner)
+}
+
+func TestSuiParsedMoveObject_DecodeNestedFields(t *testing.T) {
+	var object SuiParsedMoveObject
+	err := json.Unmarshal(
+		[]byte(`{"type":"0xa::pool::Pool","hasPublicTransfer":true,"fields":{
+			"id":{"id":"0x5"},
+			"name":"pool",
+			"config":{"type":"0xa::pool::Config","fields":{"fee":"30","admin":"0xb"}},
+			"ticks":[
+				{"type":"0xa::pool::Tick","fields":{"index":1,"liquidity":"100"}},
+				{"type":"0xa::pool::Tick","fields":{"index":2,"liquidity":"200"}}
+			],
+			"reward":{"type":"0xa::pool::Reward","fields":{"amount":"7"}},
+			"pending":null,
+			"legacyOption":{"type":"0x1::option::Option<0xa::pool::Reward>","fields":{"vec":[
+				{"type":"0xa::pool::Reward","fields":{"amount":"8"}}
+			]}},
+			"legacyNone":{"type":"0x1::option::Option<u64>","fields":{"vec":[]}},
+			"balances":{"type":"0x2::bag::Bag","fields":{"id":{"id":"0x6"},"size":"2"}}
+		}}`),
+		&object,
+	)
+	require.NoError(t, err)
+
+	type reward struct {
+		Amount SafeSuiBigInt[uint64] `json:"amount"`
+	}
+	var fields struct {
+		Id struct {
+			Id sui_types.ObjectID `json:"id"`
+		} `json:"id"`
+		Name   string `json:"name"`
+		Config struct {
+			Fee   SafeSuiBigInt[uint64] `json:"fee"`
+			Admin sui_types.SuiAddress  `json:"admin"`
+		} `json:"config"`
+		Ticks []struct {
+			Index     int                   `json:"index"`
+			Liquidity SafeSuiBigInt[uint64] `json:"liquidity"`
+		} `json:"ticks"`
+		Reward       *reward `json:"reward"`
+		Pending      *reward `json:"pending"`
+		LegacyOption *reward `json:"legacyOption"`
+		LegacyNone   *uint64 `json:"legacyNone"`
+		Balances     struct {
+			Size SafeSuiBigInt[uint64] `json:"size"`
+		} `json:"balances"`
+	}
+	err = object.DecodeFields(&fields)
+	require.NoError(t, err)
+
+	id, err := sui_types.NewObjectIdFromHex("0x5")
+	require.NoError(t, err)
+	require.Equal(t, *id, fields.Id.Id)
+	require.Equal(t, "pool", fields.Name)
+	require.Equal(t, uint64(30), fields.Config.Fee.Uint64())
+	admin, err := sui_types.NewAddressFromHex("0xb")
+	require.NoError(t, err)
+	require.Equal(t, *admin, fields.Config.Admin)
+	require.Len(t, fields.Ticks, 2)
+	require.Equal(t, 2, fields.Ticks[1].Index)
+	require.Equal(t, uint64(200), fields.Ticks[1].Liquidity.Uint64())
+	require.NotNil(t, fields.Reward)
+	require.Equal(t, uint64(7), fields.Reward.Amount.Uint64())
+	require.Nil(t, fields.Pending)
+	require.NotNil(t, fields.LegacyOption)
+	require.Equal(t, uint64(8), fields.LegacyOption.Amount.Uint64())
+	require.Nil(t, fields.LegacyNone)
+	require.Equal(t, uint64(2), fields.Balances.Size.Uint64())
+}
+
+func TestSuiObjectData_StorageRebate(t *testing.T) {
+	var data SuiObjectData
+	err := json.Unmarshal(
+		[]byte(`{"objectId":"0x5","version":"12","digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn","storageRebate":"988000"}`),
+		&data,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, data.StorageRebate)
+	require.Equal(t, uint64(988000), data.StorageRebate.Uint64())
+
+	data = SuiObjectData{}
+	err = json.Unmarshal([]byte(`{"objectId":"0x5","version":"12","digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}`), &data)
+	require.NoError(t, err)
+	require.Nil(t, data.StorageRebate)
+}
+
+func TestSuiObjectDataFilter_Combinators(t *testing.T) {
+	pkg, err := sui_types.NewObjectIdFromHex("0x2")
+	require.NoError(t, err)
+	filter := NewObjectFilterMatchAll(
+		SuiObjectDataFilter{Package: pkg},
+		*NewObjectFilterMatchNone(SuiObjectDataFilter{StructType: "0x2::coin::Coin"}),
+		*NewObjectFilterMatchAny(
+			SuiObjectDataFilter{MoveModule: &MoveModule{Package: *pkg, Module: "kiosk"}},
+			SuiObjectDataFilter{StructType: "0x2::display::Display"},
+		),
+	)
+	data, err := json.Marshal(SuiObjectResponseQuery{Filter: filter})
+	require.NoError(t, err)
+	require.JSONEq(
+		t, `{"filter":{"MatchAll":[
+			{"Package":"0x0000000000000000000000000000000000000000000000000000000000000002"},
+			{"MatchNone":[{"StructType":"0x2::coin::Coin"}]},
+			{"MatchAny":[
+				{"MoveModule":{"package":"0x0000000000000000000000000000000000000000000000000000000000000002","module":"kiosk"}},
+				{"StructType":"0x2::display::Display"}
+			]}
+		]}}`, string(data),
+	)
+}
+
+// coinBcsObject is a SUI coin in the shape returned with showBcs, the bcsBytes are the id 0x13c1...b4db
+// followed by the balance 1234567890
+const coinBcsObject = `{"objectId":"0x13c1c3d0e15b4039cec4291c75b77c972c10c8e8e70ab4ca174cf336917cb4db","version":"14924029",
+	"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn","type":"0x2::coin::Coin<0x2::sui::SUI>",
+	"bcs":{"dataType":"moveObject","type":"0x2::coin::Coin<0x2::sui::SUI>","hasPublicTransfer":true,"version":14924029,
+		"bcsBytes":"E8HD0OFbQDnOxCkcdbd8lywQyOjnCrTKF0zzNpF8tNvSApZJAAAAAA=="}}`
+
+// requireBcsRoundTrip decodes the BCS bytes of the object into T, and checks that T is encoded to the same bytes
+func requireBcsRoundTrip[T any](t *testing.T, object SuiRawMoveObject) T {
+	var v T
+	require.NoError(t, object.DecodeBcs(&v))
+	encoded, err := bcs.Marshal(v)
+	require.NoError(t, err)
+	require.Equal(t, object.BcsBytes.Data(), encoded)
+	return v
+}
+
+func TestSuiRawMoveObject_DecodeBcs(t *testing.T) {
+	var data SuiObjectData
+	err := json.Unmarshal([]byte(coinBcsObject), &data)
+	require.NoError(t, err)
+	require.NotNil(t, data.Bcs)
+	require.NotNil(t, data.Bcs.Data.MoveObject)
+	object := *data.Bcs.Data.MoveObject
+
+	coin := requireBcsRoundTrip[sui_types.Coin](t, object)
+	require.Equal(t, data.ObjectId, coin.Id)
+	require.Equal(t, uint64(1234567890), coin.Balance)
+
+	var tooShort struct {
+		Id sui_types.ObjectID
+	}
+	require.Error(t, object.DecodeBcs(&tooShort))
+	var tooLong struct {
+		Coin  sui_types.Coin
+		Extra uint64
+	}
+	require.Error(t, object.DecodeBcs(&tooLong))
+}
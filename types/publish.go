@@ -0,0 +1,59 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+)
+
+const UpgradeCapType = "0x2::package::UpgradeCap"
+
+// UpgradeCapRef finds the `0x2::package::UpgradeCap` created by publishing a package, which is required to upgrade
+// the package later. The response must be requested with `ShowObjectChanges`, since the effects do not carry types
+func (r *SuiTransactionBlockResponse) UpgradeCapRef() (*sui_types.ObjectRef, error) {
+	for _, change := range r.ObjectChanges {
+		created := change.Data.Created
+		// the struct tags are compared in the normalized form, the node may return the long address
+		if created == nil || NormalizeCoinType(created.ObjectType) != UpgradeCapType {
+			continue
+		}
+		return &sui_types.ObjectRef{
+			ObjectId: created.ObjectId,
+			Version:  created.Version.Uint64(),
+			Digest:   created.Digest.Data(),
+		}, nil
+	}
+	return nil, errors.New("no UpgradeCap created, request with ShowObjectChanges")
+}
+
+// PublishedPackageId returns the id of the package published by the transaction, which is taken from the `published`
+// object change if requested with `ShowObjectChanges`, otherwise from the effects. A newly published package is the
+// immutable object created at version 1, the objects frozen by `init`, e.g. CoinMetadata, have the version of the
+// transaction instead
+func (r *SuiTransactionBlockResponse) PublishedPackageId() (*sui_types.ObjectID, error) {
+	for _, change := range r.ObjectChanges {
+		if published := change.Data.Published; published != nil {
+			return &published.PackageId, nil
+		}
+	}
+	if r.Effects != nil && r.Effects.Data.V1 != nil {
+		var packageId *sui_types.ObjectID
+		for _, created := range r.Effects.Data.V1.Created {
+			if created.Owner.Data.Immutable == nil || created.Reference.Version != 1 {
+				continue
+			}
+			if packageId != nil {
+				return nil, errors.New("more than one package created, request with ShowObjectChanges")
+			}
+			id, err := sui_types.NewObjectIdFromHex(created.Reference.ObjectId)
+			if err != nil {
+				return nil, err
+			}
+			packageId = id
+		}
+		if packageId != nil {
+			return packageId, nil
+		}
+	}
+	return nil, errors.New("no package published, request with ShowObjectChanges or ShowEffects")
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const publishResponseJson = `{
+	"digest": "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn",
+	"effects": {
+		"messageVersion": "v1",
+		"created": [
+			{
+				"owner": {"AddressOwner": "0x000000000000000000000000000000000000000000000000000000000000000a"},
+				"reference": {"objectId": "0x1b", "version": 3, "digest": "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}
+			},
+			{
+				"owner": "Immutable",
+				"reference": {"objectId": "0x1c", "version": 1, "digest": "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}
+			}
+		]
+	},
+	"objectChanges": [
+		{
+			"type": "mutated", "sender": "0xa", "owner": {"AddressOwner": "0xa"},
+			"objectType": "0x2::coin::Coin<0x2::sui::SUI>", "objectId": "0x1a", "version": "3", "previousVersion": "2",
+			"digest": "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"
+		},
+		{
+			"type": "created", "sender": "0xa", "owner": {"AddressOwner": "0xa"},
+			"objectType": "0x2::package::UpgradeCap", "objectId": "0x1b", "version": "3",
+			"digest": "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"
+		},
+		{
+			"type": "published", "packageId": "0x1c", "version": "1",
+			"digest": "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn", "modules": ["counter"]
+		}
+	]
+}`
+
+func TestSuiTransactionBlockResponse_UpgradeCapRef(t *testing.T) {
+	var resp SuiTransactionBlockResponse
+	err := json.Unmarshal([]byte(publishResponseJson), &resp)
+	require.NoError(t, err)
+	ref, err := resp.UpgradeCapRef()
+	require.NoError(t, err)
+	require.Equal(t, "0x1b", ref.ObjectId.ShortString())
+	require.Equal(t, uint64(3), ref.Version)
+
+	resp.ObjectChanges[1].Data.Created.ObjectType =
+		"0x0000000000000000000000000000000000000000000000000000000000000002::package::UpgradeCap"
+	ref, err = resp.UpgradeCapRef()
+	require.NoError(t, err)
+	require.Equal(t, "0x1b", ref.ObjectId.ShortString())
+
+	_, err = (&SuiTransactionBlockResponse{}).UpgradeCapRef()
+	require.Error(t, err)
+}
+
+func TestSuiTransactionBlockResponse_PublishedPackageId(t *testing.T) {
+	var resp SuiTransactionBlockResponse
+	err := json.Unmarshal([]byte(publishResponseJson), &resp)
+	require.NoError(t, err)
+	packageId, err := resp.PublishedPackageId()
+	require.NoError(t, err)
+	require.Equal(t, "0x1c", packageId.ShortString())
+	require.Equal(t, []string{"counter"}, resp.ObjectChanges[2].Data.Published.Modules)
+
+	// effects only
+	resp.ObjectChanges = nil
+	packageId, err = resp.PublishedPackageId()
+	require.NoError(t, err)
+	require.Equal(t, "0x1c", packageId.ShortString())
+
+	// the CoinMetadata frozen by init is created at the version of the transaction
+	frozen := resp.Effects.Data.V1.Created[1]
+	frozen.Reference.ObjectId = "0x1d"
+	frozen.Reference.Version = 3
+	resp.Effects.Data.V1.Created = append([]OwnedObjectRef{frozen}, resp.Effects.Data.V1.Created...)
+	packageId, err = resp.PublishedPackageId()
+	require.NoError(t, err)
+	require.Equal(t, "0x1c", packageId.ShortString())
+
+	another := resp.Effects.Data.V1.Created[2]
+	another.Reference.ObjectId = "0x1e"
+	resp.Effects.Data.V1.Created = append(resp.Effects.Data.V1.Created, another)
+	_, err = resp.PublishedPackageId()
+	require.Error(t, err)
+
+	_, err = (&SuiTransactionBlockResponse{}).PublishedPackageId()
+	require.Error(t, err)
+}
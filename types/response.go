@@ -44,7 +44,27 @@ type SuiCoinMetadata struct {
 	Symbol      string             `json:"symbol"`
 }
 
+// LoadedChildObject a child object read by the transaction through the dynamic fields, at the loaded version
+type LoadedChildObject struct {
+	ObjectId       sui_types.ObjectID    `json:"objectId"`
+	SequenceNumber SafeSuiBigInt[uint64] `json:"sequenceNumber"`
+}
+
+type LoadedChildObjectsResponse struct {
+	LoadedChildObjects []LoadedChildObject `json:"loadedChildObjects"`
+}
+
 type DevInspectResult struct {
 	Err string `json:"Err,omitempty"`
 	Ok  any    `json:"Ok,omitempty"` //Result_of_Array_of_Tuple_of_uint_and_SuiExecutionResult_or_String
 }
+
+// ProtocolConfig the protocol config of the version returned by `sui_getProtocolConfig`
+type ProtocolConfig struct {
+	MinSupportedProtocolVersion SafeSuiBigInt[uint64] `json:"minSupportedProtocolVersion"`
+	MaxSupportedProtocolVersion SafeSuiBigInt[uint64] `json:"maxSupportedProtocolVersion"`
+	ProtocolVersion             SafeSuiBigInt[uint64] `json:"protocolVersion"`
+	FeatureFlags                map[string]bool       `json:"featureFlags"`
+	// Attributes the values are keyed by their types, e.g. `{"u64": "1000"}`, nil if it is not set in the version
+	Attributes map[string]map[string]string `json:"attributes"`
+}
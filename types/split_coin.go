@@ -0,0 +1,101 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+)
+
+// SplitCoinRef finds the coin created by the `index`th amount of the SplitCoins command at `command` of pt,
+// pt should be the programmable transaction which produced the response, and the response must be requested with
+// `ShowObjectChanges`.
+//
+// The node does not report which command created an object, so the correlation is a heuristic:
+//  1. the split result must be transferred by a later TransferObjects command to a pure address, which gives the owner
+//  2. the candidates are the created objects of type `0x2::coin::Coin<coinType>` owned by that address
+//  3. exactly one candidate must remain, otherwise ErrSplitCoinAmbiguous is returned. This happens when the same
+//     transaction creates more than one coin of the type for the recipient, e.g. several splits to the same address
+//
+// coinType is compared by NormalizeCoinType, e.g. `0x2::sui::SUI` or the padded form, coins split from the gas coin
+// are always SUI.
+func (r *SuiTransactionBlockResponse) SplitCoinRef(
+	pt *sui_types.ProgrammableTransaction,
+	command uint16,
+	index uint16,
+	coinType string,
+) (*sui_types.ObjectRef, error) {
+	if int(command) >= len(pt.Commands) || pt.Commands[command].SplitCoins == nil {
+		return nil, fmt.Errorf("command %d is not SplitCoins", command)
+	}
+	if int(index) >= len(pt.Commands[command].SplitCoins.Arguments) {
+		return nil, fmt.Errorf("split coins command %d has no result %d", command, index)
+	}
+	recipient, err := splitCoinRecipient(pt, command, index)
+	if err != nil {
+		return nil, err
+	}
+
+	objectType := NormalizeCoinType(fmt.Sprintf("0x2::coin::Coin<%v>", coinType))
+	var refs []*sui_types.ObjectRef
+	for _, change := range r.ObjectChanges {
+		created := change.Data.Created
+		if created == nil || NormalizeCoinType(created.ObjectType) != objectType {
+			continue
+		}
+		owner := created.Owner.ObjectOwnerInternal
+		if owner == nil || owner.AddressOwner == nil || *owner.AddressOwner != *recipient {
+			continue
+		}
+		refs = append(
+			refs, &sui_types.ObjectRef{
+				ObjectId: created.ObjectId,
+				Version:  created.Version.Uint64(),
+				Digest:   created.Digest.Data(),
+			},
+		)
+	}
+	switch len(refs) {
+	case 0:
+		return nil, fmt.Errorf("no created %v owned by %v", objectType, recipient)
+	case 1:
+		return refs[0], nil
+	default:
+		return nil, fmt.Errorf("%w: %d created %v owned by %v", ErrSplitCoinAmbiguous, len(refs), objectType, recipient)
+	}
+}
+
+// splitCoinRecipient finds the recipient of the TransferObjects command which consumes the split result
+func splitCoinRecipient(pt *sui_types.ProgrammableTransaction, command uint16, index uint16) (
+	*sui_types.SuiAddress,
+	error,
+) {
+	isSplitResult := func(arg sui_types.Argument) bool {
+		if arg.NestedResult != nil {
+			return arg.NestedResult.Result1 == command && arg.NestedResult.Result2 == index
+		}
+		return arg.Result != nil && *arg.Result == command
+	}
+	for _, cmd := range pt.Commands[command+1:] {
+		if cmd.TransferObjects == nil {
+			continue
+		}
+		for _, arg := range cmd.TransferObjects.Arguments {
+			if !isSplitResult(arg) {
+				continue
+			}
+			recipient := cmd.TransferObjects.Argument
+			if recipient.Input == nil || int(*recipient.Input) >= len(pt.Inputs) {
+				return nil, fmt.Errorf("recipient of split result %d of command %d is not an input", index, command)
+			}
+			pure := pt.Inputs[*recipient.Input].Pure
+			if pure == nil || len(*pure) != move_types.SuiAddressLen {
+				return nil, fmt.Errorf("recipient of split result %d of command %d is not an address", index, command)
+			}
+			var addr sui_types.SuiAddress
+			copy(addr[:], *pure)
+			return &addr, nil
+		}
+	}
+	return nil, fmt.Errorf("split result %d of command %d is not transferred to an address", index, command)
+}
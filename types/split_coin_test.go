@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuiTransactionBlockResponse_SplitCoinRef(t *testing.T) {
+	a, err := sui_types.NewAddressFromHex("0xa")
+	require.NoError(t, err)
+	b, err := sui_types.NewAddressFromHex("0xb")
+	require.NoError(t, err)
+	ptb := sui_types.NewProgrammableTransactionBuilder()
+	err = ptb.PaySui([]sui_types.SuiAddress{*a, *b, *a}, []uint64{1, 2, 3})
+	require.NoError(t, err)
+	pt := ptb.Finish()
+
+	created := func(owner *sui_types.SuiAddress, objectType string, id string) string {
+		return fmt.Sprintf(
+			`{"type":"created","sender":"%v","owner":{"AddressOwner":"%v"},"objectType":"%v","objectId":"%v",`+
+				`"version":"10","digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}`,
+			a, owner, objectType, id,
+		)
+	}
+	changes := fmt.Sprintf(
+		`{"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn","objectChanges":[%v,%v,%v,%v]}`,
+		created(a, "0x2::coin::Coin<0x2::sui::SUI>", "0x1a"),
+		created(b, "0x2::coin::Coin<0x2::sui::SUI>", "0x1b"),
+		created(a, "0x2::coin::Coin<0x2::sui::SUI>", "0x1c"),
+		created(b, "0x2::kiosk::Kiosk", "0x1d"),
+	)
+	var resp SuiTransactionBlockResponse
+	err = json.Unmarshal([]byte(changes), &resp)
+	require.NoError(t, err)
+
+	ref, err := resp.SplitCoinRef(&pt, 0, 1, SuiCoinType)
+	require.NoError(t, err)
+	require.Equal(t, "0x1b", ref.ObjectId.ShortString())
+	require.Equal(t, uint64(10), ref.Version)
+
+	// the coin type is compared in the normalized form
+	ref, err = resp.SplitCoinRef(
+		&pt, 0, 1, "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "0x1b", ref.ObjectId.ShortString())
+
+	_, err = resp.SplitCoinRef(&pt, 0, 0, SuiCoinType)
+	require.ErrorIs(t, err, ErrSplitCoinAmbiguous)
+
+	_, err = resp.SplitCoinRef(&pt, 0, 3, SuiCoinType)
+	require.Error(t, err)
+	_, err = resp.SplitCoinRef(&pt, 1, 0, SuiCoinType)
+	require.Error(t, err)
+}
@@ -1,6 +1,12 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/coming-chat/go-sui/v2/lib"
 	"github.com/coming-chat/go-sui/v2/sui_types"
 )
@@ -21,11 +27,22 @@ type GasCostSummary struct {
 	NonRefundableStorageFee SafeSuiBigInt[uint64] `json:"nonRefundableStorageFee"`
 }
 
+// EffectiveGasUnits the computation cost is charged in gas units times the gas price, so dividing it by the
+// reference gas price of the executed epoch gives the gas units, which do not vary with the gas price
+func (g GasCostSummary) EffectiveGasUnits(referenceGasPrice uint64) uint64 {
+	if referenceGasPrice == 0 {
+		return 0
+	}
+	return g.ComputationCost.Uint64() / referenceGasPrice
+}
+
 const (
 	ExecutionStatusSuccess = "success"
 	ExecutionStatusFailure = "failure"
 )
 
+const ExecutionErrorInsufficientGas = "InsufficientGas"
+
 type ExecutionStatus struct {
 	Status string `json:"status"`
 	Error  string `json:"error,omitempty"`
@@ -106,11 +123,74 @@ func (t SuiTransactionBlockEffects) IsSuccess() bool {
 	return t.V1.Status.Status == ExecutionStatusSuccess
 }
 
+// ModifiedAtVersion returns the version of the object before it was mutated or deleted by the transaction.
+// ok is false if the object was not modified, or the effects were produced by a node which omits `modifiedAtVersions`
+func (t SuiTransactionBlockEffects) ModifiedAtVersion(objectId sui_types.ObjectID) (
+	version sui_types.SequenceNumber,
+	ok bool,
+) {
+	if t.V1 == nil {
+		return 0, false
+	}
+	for _, modified := range t.V1.ModifiedAtVersions {
+		if modified.ObjectId == objectId {
+			return modified.SequenceNumber.Uint64(), true
+		}
+	}
+	return 0, false
+}
+
+// Dependencies returns the digests of the transactions this transaction depends on, which are the edges of
+// the causal graph. Every digest must be 32 bytes, an invalid base58 string is decoded as empty by lib.Base58
+func (t SuiTransactionBlockEffects) Dependencies() ([]sui_types.TransactionDigest, error) {
+	if t.V1 == nil {
+		return nil, errors.New("nil transaction effects")
+	}
+	for i, dependency := range t.V1.Dependencies {
+		if dependency.Length() != sui_types.DigestLength {
+			return nil, fmt.Errorf("invalid dependency %d: %v", i, dependency)
+		}
+	}
+	return t.V1.Dependencies, nil
+}
+
+// MutatedAndCreatedRefs returns the refs of the created and mutated objects owned by the address owner after
+// the transaction, including the gas object, which can be used as the inputs of the next transaction of owner without
+// fetching the objects. The shared, immutable and object-owned objects are filtered out
+func (t SuiTransactionBlockEffects) MutatedAndCreatedRefs(owner sui_types.SuiAddress) ([]*sui_types.ObjectRef, error) {
+	if t.V1 == nil {
+		return nil, errors.New("nil transaction effects")
+	}
+	var refs []*sui_types.ObjectRef
+	for _, objs := range [][]OwnedObjectRef{t.V1.Created, t.V1.Mutated} {
+		for _, obj := range objs {
+			addressOwner := obj.Owner.Data.AddressOwner
+			if addressOwner == nil || *addressOwner != owner {
+				continue
+			}
+			ref, err := obj.Reference.ObjectRef()
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// IsInsufficientGas returns true if the execution failed since the gas budget was used up
+func (t SuiTransactionBlockEffects) IsInsufficientGas() bool {
+	return t.V1 != nil && t.V1.Status.Status == ExecutionStatusFailure &&
+		strings.HasPrefix(t.V1.Status.Error, ExecutionErrorInsufficientGas)
+}
+
 const (
 	SuiTransactionBlockKindSuiChangeEpoch             = "ChangeEpoch"
 	SuiTransactionBlockKindSuiConsensusCommitPrologue = "ConsensusCommitPrologue"
 	SuiTransactionBlockKindGenesis                    = "Genesis"
 	SuiTransactionBlockKindProgrammableTransaction    = "ProgrammableTransaction"
+	SuiTransactionBlockKindConsensusCommitPrologueV2  = "ConsensusCommitPrologueV2"
+	SuiTransactionBlockKindConsensusCommitPrologueV3  = "ConsensusCommitPrologueV3"
 )
 
 type SuiTransactionBlockKind = lib.TagJson[TransactionBlockKind]
@@ -126,6 +206,15 @@ type TransactionBlockKind struct {
 	/// A series of transactions where the results of one transaction can be used in future
 	/// transactions
 	ProgrammableTransaction *SuiProgrammableTransactionBlock `json:"ProgrammableTransaction,omitempty"`
+	/// Newer versions of ConsensusCommitPrologue, must be declared after it since the tag is matched by prefix
+	ConsensusCommitPrologueV2 *SuiConsensusCommitPrologueV2 `json:"ConsensusCommitPrologueV2,omitempty"`
+	ConsensusCommitPrologueV3 *SuiConsensusCommitPrologueV3 `json:"ConsensusCommitPrologueV3,omitempty"`
+	/// A system transaction updating the active JWKs of zklogin
+	AuthenticatorStateUpdate *SuiAuthenticatorStateUpdate `json:"AuthenticatorStateUpdate,omitempty"`
+	/// A system transaction updating the on-chain randomness
+	RandomnessStateUpdate *SuiRandomnessStateUpdate `json:"RandomnessStateUpdate,omitempty"`
+	/// A system transaction running the end of epoch transactions, e.g. ChangeEpoch
+	EndOfEpochTransaction *SuiEndOfEpochTransaction `json:"EndOfEpochTransaction,omitempty"`
 	// .. more transaction types go here
 }
 
@@ -137,6 +226,45 @@ func (t TransactionBlockKind) Content() string {
 	return ""
 }
 
+func (t TransactionBlockKind) Type() sui_types.TransactionKindType {
+	switch {
+	case t.ProgrammableTransaction != nil:
+		return sui_types.TransactionKindProgrammableTransaction
+	case t.ChangeEpoch != nil:
+		return sui_types.TransactionKindChangeEpoch
+	case t.Genesis != nil:
+		return sui_types.TransactionKindGenesis
+	case t.ConsensusCommitPrologue != nil:
+		return sui_types.TransactionKindConsensusCommitPrologue
+	case t.ConsensusCommitPrologueV2 != nil:
+		return sui_types.TransactionKindConsensusCommitPrologueV2
+	case t.ConsensusCommitPrologueV3 != nil:
+		return sui_types.TransactionKindConsensusCommitPrologueV3
+	case t.AuthenticatorStateUpdate != nil:
+		return sui_types.TransactionKindAuthenticatorStateUpdate
+	case t.RandomnessStateUpdate != nil:
+		return sui_types.TransactionKindRandomnessStateUpdate
+	case t.EndOfEpochTransaction != nil:
+		return sui_types.TransactionKindEndOfEpochTransaction
+	default:
+		return sui_types.TransactionKindUnknown
+	}
+}
+
+func (t TransactionBlockKind) IsSystemTx() bool {
+	return t.Type() != sui_types.TransactionKindProgrammableTransaction &&
+		t.Type() != sui_types.TransactionKindUnknown
+}
+
+// IsSystemTransaction returns true if the transaction is created by validators rather than users, which indexers
+// usually skip, the kinds are those of TransactionBlockKind.IsSystemTx
+func IsSystemTransaction(transaction *SuiTransactionBlock) bool {
+	if transaction == nil || transaction.Data.Data.V1 == nil {
+		return false
+	}
+	return transaction.Data.Data.V1.Transaction.Data.IsSystemTx()
+}
+
 type SuiChangeEpoch struct {
 	Epoch                 SafeSuiBigInt[EpochId] `json:"epoch"`
 	StorageCharge         uint64                 `json:"storage_charge"`
@@ -150,9 +278,64 @@ type SuiGenesisTransaction struct {
 }
 
 type SuiConsensusCommitPrologue struct {
-	Epoch             uint64 `json:"epoch"`
-	Round             uint64 `json:"round"`
-	CommitTimestampMs uint64 `json:"commit_timestamp_ms"`
+	Epoch             SafeSuiBigInt[EpochId] `json:"epoch"`
+	Round             SafeSuiBigInt[uint64]  `json:"round"`
+	CommitTimestampMs SafeSuiBigInt[uint64]  `json:"commit_timestamp_ms"`
+}
+
+type SuiConsensusCommitPrologueV2 struct {
+	Epoch                 SafeSuiBigInt[EpochId]          `json:"epoch"`
+	Round                 SafeSuiBigInt[uint64]           `json:"round"`
+	CommitTimestampMs     SafeSuiBigInt[uint64]           `json:"commit_timestamp_ms"`
+	ConsensusCommitDigest sui_types.ConsensusCommitDigest `json:"consensus_commit_digest"`
+}
+
+type SuiConsensusCommitPrologueV3 struct {
+	Epoch                                 SafeSuiBigInt[EpochId]          `json:"epoch"`
+	Round                                 SafeSuiBigInt[uint64]           `json:"round"`
+	SubDagIndex                           *SafeSuiBigInt[uint64]          `json:"sub_dag_index,omitempty"`
+	CommitTimestampMs                     SafeSuiBigInt[uint64]           `json:"commit_timestamp_ms"`
+	ConsensusCommitDigest                 sui_types.ConsensusCommitDigest `json:"consensus_commit_digest"`
+	ConsensusDeterminedVersionAssignments interface{}                     `json:"consensus_determined_version_assignments"`
+}
+
+type SuiAuthenticatorStateUpdate struct {
+	Epoch         SafeSuiBigInt[EpochId] `json:"epoch"`
+	Round         SafeSuiBigInt[uint64]  `json:"round"`
+	NewActiveJwks []interface{}          `json:"new_active_jwks"`
+}
+
+type SuiRandomnessStateUpdate struct {
+	Epoch           SafeSuiBigInt[EpochId] `json:"epoch"`
+	RandomnessRound SafeSuiBigInt[uint64]  `json:"randomness_round"`
+	RandomBytes     []int                  `json:"random_bytes"`
+}
+
+type SuiEndOfEpochTransaction struct {
+	Transactions []interface{} `json:"transactions"`
+}
+
+// CommitPrologue returns the epoch, round and commit timestamp shared by all versions of ConsensusCommitPrologue,
+// nil if the transaction is not a consensus commit prologue
+func (t TransactionBlockKind) CommitPrologue() *SuiConsensusCommitPrologue {
+	switch {
+	case t.ConsensusCommitPrologue != nil:
+		return t.ConsensusCommitPrologue
+	case t.ConsensusCommitPrologueV2 != nil:
+		return &SuiConsensusCommitPrologue{
+			Epoch:             t.ConsensusCommitPrologueV2.Epoch,
+			Round:             t.ConsensusCommitPrologueV2.Round,
+			CommitTimestampMs: t.ConsensusCommitPrologueV2.CommitTimestampMs,
+		}
+	case t.ConsensusCommitPrologueV3 != nil:
+		return &SuiConsensusCommitPrologue{
+			Epoch:             t.ConsensusCommitPrologueV3.Epoch,
+			Round:             t.ConsensusCommitPrologueV3.Round,
+			CommitTimestampMs: t.ConsensusCommitPrologueV3.CommitTimestampMs,
+		}
+	default:
+		return nil
+	}
 }
 
 type SuiProgrammableTransactionBlock struct {
@@ -190,7 +373,7 @@ type ObjectChange struct {
 		PackageId sui_types.ObjectID                      `json:"packageId"`
 		Version   SafeSuiBigInt[sui_types.SequenceNumber] `json:"version"`
 		Digest    sui_types.ObjectDigest                  `json:"digest"`
-		Nodules   []string                                `json:"nodules"`
+		Modules   []string                                `json:"modules"`
 	} `json:"published,omitempty"`
 	/// Transfer objects to new address / wrap in another object
 	Transferred *struct {
@@ -251,10 +434,19 @@ type BalanceChange struct {
 	Amount string `json:"amount"`
 }
 
+func (b *BalanceChange) UnmarshalJSON(data []byte) error {
+	type balanceChange BalanceChange
+	if err := json.Unmarshal(data, (*balanceChange)(b)); err != nil {
+		return err
+	}
+	b.CoinType = NormalizeCoinType(b.CoinType)
+	return nil
+}
+
 type SuiTransactionBlockResponse struct {
 	Digest                  sui_types.TransactionDigest              `json:"digest"`
 	Transaction             *SuiTransactionBlock                     `json:"transaction,omitempty"`
-	RawTransaction          []byte                                   `json:"rawTransaction,omitempty"`
+	RawTransaction          lib.Base64Data                           `json:"rawTransaction,omitempty"`
 	Effects                 *lib.TagJson[SuiTransactionBlockEffects] `json:"effects,omitempty"`
 	Events                  []SuiEvent                               `json:"events,omitempty"`
 	TimestampMs             *SafeSuiBigInt[uint64]                   `json:"timestampMs,omitempty"`
@@ -266,6 +458,44 @@ type SuiTransactionBlockResponse struct {
 	Errors []string `json:"errors,omitempty"`
 }
 
+// RawTransactionData returns the BCS bytes of TransactionData in RawTransaction, which is requested with `ShowRawInput`.
+// The bytes can be fed to sui_types.ComputeTransactionDigest to verify the digest of the response independently
+func (r *SuiTransactionBlockResponse) RawTransactionData() ([]byte, error) {
+	if len(r.RawTransaction) == 0 {
+		return nil, errors.New("no raw transaction, request with ShowRawInput")
+	}
+	txBytes, _, err := sui_types.ParseSenderSignedData(r.RawTransaction)
+	return txBytes, err
+}
+
+// Expiration decodes the expiration of the transaction from RawTransaction, which is requested with `ShowRawInput`,
+// since the json of the transaction has no expiration. The None variant is set if the transaction never expires,
+// see sui_types.TransactionExpiration.EpochBound
+func (r *SuiTransactionBlockResponse) Expiration() (*sui_types.TransactionExpiration, error) {
+	txBytes, err := r.RawTransactionData()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := sui_types.DecodeTransactionData(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &tx.V1.Expiration, nil
+}
+
+// VerifyRawTransactionDigest checks that the digest of RawTransaction matches the Digest of the response
+func (r *SuiTransactionBlockResponse) VerifyRawTransactionDigest() error {
+	txBytes, err := r.RawTransactionData()
+	if err != nil {
+		return err
+	}
+	digest := sui_types.ComputeTransactionDigest(txBytes)
+	if !bytes.Equal(digest.Data(), r.Digest.Data()) {
+		return fmt.Errorf("transaction digest mismatch, computed %v but got %v", digest, r.Digest)
+	}
+	return nil
+}
+
 type ReturnValueType interface{}
 type MutableReferenceOutputType interface{}
 type ExecutionResultType struct {
@@ -301,6 +531,8 @@ type TransactionFilter struct {
 type SuiTransactionBlockResponseOptions struct {
 	/* Whether to show transaction input data. Default to be false. */
 	ShowInput bool `json:"showInput,omitempty"`
+	/* Whether to show bcs-encoded transaction input data, see SuiTransactionBlockResponse.RawTransactionData */
+	ShowRawInput bool `json:"showRawInput,omitempty"`
 	/* Whether to show transaction effects. Default to be false. */
 	ShowEffects bool `json:"showEffects,omitempty"`
 	/* Whether to show transaction events. Default to be false. */
@@ -0,0 +1,275 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/fardream/go-bcs/bcs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionBlockKind_ConsensusCommitPrologue(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want sui_types.TransactionKindType
+	}{
+		{
+			name: "v1",
+			json: `{"kind":"ConsensusCommitPrologue","epoch":"100","round":"21","commit_timestamp_ms":"1690000000000"}`,
+			want: sui_types.TransactionKindConsensusCommitPrologue,
+		},
+		{
+			name: "v2",
+			json: `{"kind":"ConsensusCommitPrologueV2","epoch":"100","round":"21","commit_timestamp_ms":"1690000000000",` +
+				`"consensus_commit_digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}`,
+			want: sui_types.TransactionKindConsensusCommitPrologueV2,
+		},
+		{
+			name: "v3",
+			json: `{"kind":"ConsensusCommitPrologueV3","epoch":"100","round":"21","sub_dag_index":null,` +
+				`"commit_timestamp_ms":"1690000000000","consensus_commit_digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn",` +
+				`"consensus_determined_version_assignments":{"CancelledTransactions":[]}}`,
+			want: sui_types.TransactionKindConsensusCommitPrologueV3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var kind SuiTransactionBlockKind
+			err := json.Unmarshal([]byte(tt.json), &kind)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, kind.Data.Type())
+			require.True(t, kind.Data.IsSystemTx())
+
+			prologue := kind.Data.CommitPrologue()
+			require.NotNil(t, prologue)
+			require.Equal(t, uint64(100), prologue.Epoch.Uint64())
+			require.Equal(t, uint64(21), prologue.Round.Uint64())
+			require.Equal(t, uint64(1690000000000), prologue.CommitTimestampMs.Uint64())
+		})
+	}
+}
+
+func TestIsSystemTransaction(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+		want bool
+	}{
+		{
+			name: "programmable",
+			kind: `{"kind":"ProgrammableTransaction","inputs":[],"transactions":[]}`,
+			want: false,
+		},
+		{
+			name: "change epoch",
+			kind: `{"kind":"ChangeEpoch","epoch":"100","storage_charge":0,"computation_charge":0,"storage_rebate":0,` +
+				`"epoch_start_timestamp_ms":1690000000000}`,
+			want: true,
+		},
+		{
+			name: "genesis",
+			kind: `{"kind":"Genesis","objects":[]}`,
+			want: true,
+		},
+		{
+			name: "consensus commit prologue",
+			kind: `{"kind":"ConsensusCommitPrologue","epoch":"100","round":"21","commit_timestamp_ms":"1690000000000"}`,
+			want: true,
+		},
+		{
+			name: "authenticator state update",
+			kind: `{"kind":"AuthenticatorStateUpdate","epoch":"100","round":"21","new_active_jwks":[]}`,
+			want: true,
+		},
+		{
+			name: "randomness state update",
+			kind: `{"kind":"RandomnessStateUpdate","epoch":"100","randomness_round":"21","random_bytes":[1,2,3]}`,
+			want: true,
+		},
+		{
+			name: "end of epoch",
+			kind: `{"kind":"EndOfEpochTransaction","transactions":[]}`,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"data":{"messageVersion":"v1","transaction":` + tt.kind + `,` +
+				`"sender":"0x0000000000000000000000000000000000000000000000000000000000000000",` +
+				`"gasData":{"payment":[],"owner":"0x0000000000000000000000000000000000000000000000000000000000000000",` +
+				`"price":"1","budget":"0"}},"txSignatures":[]}`
+			var tx SuiTransactionBlock
+			err := json.Unmarshal([]byte(data), &tx)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, IsSystemTransaction(&tx))
+			gasOwner, err := tx.Data.Data.V1.GasData.OwnerAddress()
+			require.NoError(t, err)
+			require.Equal(t, sui_types.SuiAddress{}, *gasOwner)
+		})
+	}
+	require.False(t, IsSystemTransaction(nil))
+}
+
+func TestGasCostSummary_EffectiveGasUnits(t *testing.T) {
+	summary := GasCostSummary{ComputationCost: NewSafeSuiBigInt(uint64(1_500_000))}
+	require.Equal(t, uint64(2000), summary.EffectiveGasUnits(750))
+	require.Equal(t, uint64(0), summary.EffectiveGasUnits(0))
+}
+
+func TestSuiTransactionBlockResponse_VerifyRawTransactionDigest(t *testing.T) {
+	sender, err := sui_types.NewAddressFromHex("0xa")
+	require.NoError(t, err)
+	ptb := sui_types.NewProgrammableTransactionBuilder()
+	err = ptb.PayAllSui(*sender)
+	require.NoError(t, err)
+	gas := &sui_types.ObjectRef{ObjectId: *sender, Version: 1, Digest: make([]byte, 32)}
+	tx := sui_types.NewProgrammable(*sender, []*sui_types.ObjectRef{gas}, ptb.Finish(), 1000, 1)
+	txBytes, err := bcs.Marshal(tx)
+	require.NoError(t, err)
+	digest := sui_types.ComputeTransactionDigest(txBytes)
+	require.Equal(t, sui_types.UseDefaultHash(sui_types.BcsSignable[sui_types.TransactionData]{Data: tx}), digest.Data())
+
+	raw, err := bcs.Marshal(
+		[]sui_types.SenderSignedTransaction{
+			{
+				IntentMessage: sui_types.NewIntentMessage(sui_types.DefaultIntent(), tx),
+				TxSignatures:  [][]byte{make([]byte, 97)},
+			},
+		},
+	)
+	require.NoError(t, err)
+	resp := SuiTransactionBlockResponse{Digest: digest, RawTransaction: raw}
+	require.NoError(t, resp.VerifyRawTransactionDigest())
+
+	resp.Digest = sui_types.ComputeTransactionDigest([]byte("other"))
+	require.Error(t, resp.VerifyRawTransactionDigest())
+}
+
+func TestSuiTransactionBlockResponse_Expiration(t *testing.T) {
+	sender := sui_types.SuiAddress{31: 0xa}
+	object := &sui_types.ObjectRef{ObjectId: sui_types.ObjectID{31: 2}, Version: 1, Digest: make([]byte, 32)}
+	gas := &sui_types.ObjectRef{ObjectId: sui_types.ObjectID{31: 1}, Version: 1, Digest: make([]byte, 32)}
+	rawTransaction := func(expiration sui_types.TransactionExpiration) lib.Base64Data {
+		tx, err := sui_types.NewTransferObjectsWithGasCoins(
+			sender, sender, []*sui_types.ObjectRef{object}, []*sui_types.ObjectRef{gas}, 1000, 1,
+		)
+		require.NoError(t, err)
+		tx.V1.Expiration = expiration
+		raw, err := bcs.Marshal(
+			[]sui_types.SenderSignedTransaction{
+				{
+					IntentMessage: sui_types.NewIntentMessage(sui_types.DefaultIntent(), tx),
+					TxSignatures:  [][]byte{make([]byte, 97)},
+				},
+			},
+		)
+		require.NoError(t, err)
+		return raw
+	}
+
+	resp := SuiTransactionBlockResponse{RawTransaction: rawTransaction(sui_types.TransactionExpiration{None: &lib.EmptyEnum{}})}
+	expiration, err := resp.Expiration()
+	require.NoError(t, err)
+	require.NotNil(t, expiration.None)
+	_, ok := expiration.EpochBound()
+	require.False(t, ok)
+
+	epoch := sui_types.EpochId(300)
+	resp = SuiTransactionBlockResponse{RawTransaction: rawTransaction(sui_types.TransactionExpiration{Epoch: &epoch})}
+	expiration, err = resp.Expiration()
+	require.NoError(t, err)
+	bound, ok := expiration.EpochBound()
+	require.True(t, ok)
+	require.Equal(t, epoch, bound)
+
+	resp = SuiTransactionBlockResponse{}
+	_, err = resp.Expiration()
+	require.Error(t, err)
+}
+
+func TestSuiTransactionBlockEffects_ModifiedAtVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		want   sui_types.SequenceNumber
+		wantOk bool
+	}{
+		{
+			name:   "modified",
+			json:   `{"messageVersion":"v1","modifiedAtVersions":[{"objectId":"0x5","sequenceNumber":"12"}]}`,
+			want:   12,
+			wantOk: true,
+		},
+		{
+			name: "not modified",
+			json: `{"messageVersion":"v1","modifiedAtVersions":[{"objectId":"0x6","sequenceNumber":"12"}]}`,
+		},
+		{
+			name: "absent",
+			json: `{"messageVersion":"v1"}`,
+		},
+	}
+	objectId, err := sui_types.NewObjectIdFromHex("0x5")
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var effects lib.TagJson[SuiTransactionBlockEffects]
+			err := json.Unmarshal([]byte(tt.json), &effects)
+			require.NoError(t, err)
+			version, ok := effects.Data.ModifiedAtVersion(*objectId)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want, version)
+		})
+	}
+}
+
+func TestSuiTransactionBlockEffects_Dependencies(t *testing.T) {
+	var effects lib.TagJson[SuiTransactionBlockEffects]
+	err := json.Unmarshal(
+		[]byte(`{"messageVersion":"v1","dependencies":["HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"]}`), &effects,
+	)
+	require.NoError(t, err)
+	dependencies, err := effects.Data.Dependencies()
+	require.NoError(t, err)
+	require.Len(t, dependencies, 1)
+	require.Equal(t, "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn", dependencies[0].String())
+
+	err = json.Unmarshal([]byte(`{"messageVersion":"v1","dependencies":["0OIl"]}`), &effects)
+	require.NoError(t, err)
+	_, err = effects.Data.Dependencies()
+	require.Error(t, err)
+}
+
+func TestSuiTransactionBlockEffects_MutatedAndCreatedRefs(t *testing.T) {
+	objectRef := func(id, owner string) string {
+		return `{"owner":` + owner + `,"reference":{"objectId":"` + id + `","version":7,` +
+			`"digest":"HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn"}}`
+	}
+	var effects lib.TagJson[SuiTransactionBlockEffects]
+	err := json.Unmarshal(
+		[]byte(`{"messageVersion":"v1",`+
+			`"created":[`+objectRef("0x11", `{"AddressOwner":"0x1"}`)+`,`+objectRef("0x12", `{"AddressOwner":"0x2"}`)+`,`+
+			objectRef("0x13", `{"Shared":{"initial_shared_version":7}}`)+`,`+objectRef("0x14", `"Immutable"`)+`],`+
+			`"mutated":[`+objectRef("0x15", `{"AddressOwner":"0x1"}`)+`,`+objectRef("0x16", `{"ObjectOwner":"0x1"}`)+`]}`),
+		&effects,
+	)
+	require.NoError(t, err)
+	owner, err := sui_types.NewAddressFromHex("0x1")
+	require.NoError(t, err)
+	refs, err := effects.Data.MutatedAndCreatedRefs(*owner)
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+	for i, id := range []string{"0x11", "0x15"} {
+		objectId, err := sui_types.NewObjectIdFromHex(id)
+		require.NoError(t, err)
+		require.Equal(t, *objectId, refs[i].ObjectId)
+		require.Equal(t, sui_types.SequenceNumber(7), refs[i].Version)
+		require.Equal(t, "HvbE2UZny6cP4KukaXetmj4jjpKTDTjVo23XEcu7VgSn", refs[i].Digest.String())
+	}
+
+	_, err = SuiTransactionBlockEffects{}.MutatedAndCreatedRefs(*owner)
+	require.Error(t, err)
+}
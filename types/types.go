@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/coming-chat/go-sui/v2/lib"
+	"github.com/coming-chat/go-sui/v2/move_types"
 	"github.com/coming-chat/go-sui/v2/sui_types"
 	"strings"
 )
 
 const (
 	SuiCoinType   = "0x2::sui::SUI"
+	SuiDecimals   = sui_types.SuiDecimals
 	DevNetRpcUrl  = "https://fullnode.devnet.sui.io"
 	TestnetRpcUrl = "https://fullnode.testnet.sui.io"
 	MainnetRpcUrl = "https://fullnode.mainnet.sui.io"
@@ -39,11 +41,11 @@ type ModulePublish struct {
 	Modules [][]byte `json:"modules"`
 }
 type MoveCall struct {
-	Package  sui_types.ObjectID `json:"package"`
-	Module   string             `json:"module"`
-	Function string             `json:"function"`
-	TypeArgs []interface{}      `json:"typeArguments"`
-	Args     []interface{}      `json:"arguments"`
+	Package  sui_types.ObjectID   `json:"package"`
+	Module   string               `json:"module"`
+	Function string               `json:"function"`
+	TypeArgs []move_types.TypeTag `json:"typeArguments"`
+	Args     []interface{}        `json:"arguments"`
 }
 type TransferSui struct {
 	Recipient sui_types.SuiAddress `json:"recipient"`
@@ -80,6 +82,8 @@ type SingleTransactionKind struct {
 	PayAllSui      *PayAllSui      `json:"PayAllSui,omitempty"`
 }
 
+// SenderSignedData is the legacy json of a signed transaction,
+// the node returns SuiTransactionBlock instead: the transaction data tagged by `messageVersion` with `txSignatures`
 type SenderSignedData struct {
 	Transactions []SingleTransactionKind `json:"transactions,omitempty"`
 